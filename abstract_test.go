@@ -145,10 +145,10 @@ func TestIsTypeOfUsedToResolveRuntimeTypeForInterface(t *testing.T) {
 		Errors: nil,
 	}
 
-	result := graphql.Graphql(graphql.Params{
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
 		Schema:        schema,
 		RequestString: query,
-	})
+	}))
 	if len(result.Errors) != 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
@@ -251,11 +251,12 @@ func TestIsTypeOfUsedToResolveRuntimeTypeForUnion(t *testing.T) {
 
 	query := `{
       pets {
-        name
         ... on Dog {
+          name
           woofs
         }
         ... on Cat {
+          name
           meows
         }
       }
@@ -277,10 +278,10 @@ func TestIsTypeOfUsedToResolveRuntimeTypeForUnion(t *testing.T) {
 		Errors: nil,
 	}
 
-	result := graphql.Graphql(graphql.Params{
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
 		Schema:        schema,
 		RequestString: query,
-	})
+	}))
 
 	if len(result.Errors) != 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
@@ -441,14 +442,15 @@ func TestResolveTypeOnInterfaceYieldsUsefulError(t *testing.T) {
 			gqlerrors.FormattedError{
 				Message:   `Runtime Object type "Human" is not a possible type for "Pet".`,
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"pets", 2},
 			},
 		},
 	}
 
-	result := graphql.Graphql(graphql.Params{
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
 		Schema:        schema,
 		RequestString: query,
-	})
+	}))
 	if len(result.Errors) == 0 {
 		t.Fatalf("wrong result, expected errors: %v, got: %v", len(expected.Errors), len(result.Errors))
 	}
@@ -568,11 +570,12 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 
 	query := `{
       pets {
-        name
         ... on Dog {
+          name
           woofs
         }
         ... on Cat {
+          name
           meows
         }
       }
@@ -596,14 +599,15 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 			gqlerrors.FormattedError{
 				Message:   `Runtime Object type "Human" is not a possible type for "Pet".`,
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"pets", 2},
 			},
 		},
 	}
 
-	result := graphql.Graphql(graphql.Params{
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
 		Schema:        schema,
 		RequestString: query,
-	})
+	}))
 	if len(result.Errors) == 0 {
 		t.Fatalf("wrong result, expected errors: %v, got: %v", len(expected.Errors), len(result.Errors))
 	}
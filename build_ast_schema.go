@@ -0,0 +1,578 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+/**
+ * This takes the ast of a schema document produced by the parse function of
+ * the language/parser package.
+ *
+ * Given that AST it constructs a Schema. The resulting schema has no
+ * resolve methods, so execution will use default resolvers, unless Go
+ * functions are attached to it with BindResolvers.
+ *
+ * The types named queryTypeName and mutationTypeName (optional, may be empty)
+ * become the root types of the schema. Type extensions add their fields and
+ * interfaces to the object type they extend. The @deprecated directives of
+ * fields, arguments, enum values and input fields deprecate them.
+ */
+func BuildASTSchema(doc *ast.Document, queryTypeName string, mutationTypeName string) (Schema, error) {
+	if doc == nil {
+		return Schema{}, errors.New("Must provide a document ast.")
+	}
+	if queryTypeName == "" {
+		return Schema{}, errors.New("Must provide a queryTypeName.")
+	}
+
+	builder := &astSchemaBuilder{
+		typeDefs: map[string]ast.Node{},
+		typeMap: map[string]Type{
+			"String":  String,
+			"Int":     Int,
+			"Float":   Float,
+			"Boolean": Boolean,
+			"ID":      ID,
+		},
+	}
+	extensions := []*ast.TypeExtensionDefinition{}
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.ObjectDefinition, *ast.InterfaceDefinition, *ast.UnionDefinition,
+			*ast.ScalarDefinition, *ast.EnumDefinition, *ast.InputObjectDefinition:
+			typeName := definitionName(definition)
+			if _, ok := builder.typeMap[typeName]; ok {
+				return Schema{}, fmt.Errorf(`Type "%v" is a built-in type and cannot be redefined.`, typeName)
+			}
+			if _, ok := builder.typeDefs[typeName]; ok {
+				return Schema{}, fmt.Errorf(`Type "%v" was defined more than once.`, typeName)
+			}
+			builder.typeDefs[typeName] = definition
+			builder.typeNames = append(builder.typeNames, typeName)
+		case *ast.TypeExtensionDefinition:
+			extensions = append(extensions, definition)
+		}
+	}
+
+	if _, ok := builder.typeDefs[queryTypeName].(*ast.ObjectDefinition); !ok {
+		return Schema{}, fmt.Errorf(`Specified query type "%v" not found in document.`, queryTypeName)
+	}
+	if mutationTypeName != "" {
+		if _, ok := builder.typeDefs[mutationTypeName].(*ast.ObjectDefinition); !ok {
+			return Schema{}, fmt.Errorf(`Specified mutation type "%v" not found in document.`, mutationTypeName)
+		}
+	}
+
+	// Named types are all created before any field is defined, so that fields
+	// can refer to any type of the document, including their own.
+	err := builder.makeNamedTypes()
+	if err != nil {
+		return Schema{}, err
+	}
+	err = builder.makeFields(extensions)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	config := SchemaConfig{
+		Query: builder.typeMap[queryTypeName].(*Object),
+	}
+	if mutationTypeName != "" {
+		config.Mutation = builder.typeMap[mutationTypeName].(*Object)
+	}
+	return NewSchema(config)
+}
+
+type astSchemaBuilder struct {
+	// type definitions of the document, by name and in document order
+	typeDefs  map[string]ast.Node
+	typeNames []string
+
+	typeMap map[string]Type
+}
+
+func definitionName(definition ast.Node) string {
+	if definition, ok := definition.(interface {
+		GetName() *ast.Name
+	}); ok && definition.GetName() != nil {
+		return definition.GetName().Value
+	}
+	return ""
+}
+
+func namedTypeName(namedAST *ast.Named) string {
+	if namedAST.Name != nil {
+		return namedAST.Name.Value
+	}
+	return ""
+}
+
+func (b *astSchemaBuilder) makeNamedTypes() error {
+	// Interfaces are created before objects, which register themselves as
+	// implementations of their interfaces, and objects before the unions
+	// holding them.
+	for _, kind := range []string{"leaf", "interface", "object", "union"} {
+		for _, typeName := range b.typeNames {
+			var err error
+			switch def := b.typeDefs[typeName].(type) {
+			case *ast.ScalarDefinition:
+				if kind == "leaf" {
+					b.typeMap[typeName] = makeScalarDef(def)
+				}
+			case *ast.EnumDefinition:
+				if kind == "leaf" {
+					b.typeMap[typeName] = makeEnumDef(def)
+				}
+			case *ast.InputObjectDefinition:
+				// The fields of input objects are defined by makeFields.
+				if kind == "leaf" {
+					b.typeMap[typeName] = &InputObject{
+						Name:       typeName,
+						typeConfig: InputObjectConfig{Name: typeName},
+					}
+				}
+			case *ast.InterfaceDefinition:
+				if kind == "interface" {
+					b.typeMap[typeName] = NewInterface(InterfaceConfig{
+						Name:   typeName,
+						Fields: FieldConfigMap{},
+					})
+				}
+			case *ast.ObjectDefinition:
+				if kind == "object" {
+					b.typeMap[typeName], err = b.makeObjectDef(def)
+				}
+			case *ast.UnionDefinition:
+				if kind == "union" {
+					b.typeMap[typeName], err = b.makeUnionDef(def)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			if ttype, ok := b.typeMap[typeName]; ok && ttype.GetError() != nil {
+				return ttype.GetError()
+			}
+		}
+	}
+	return nil
+}
+
+func (b *astSchemaBuilder) makeObjectDef(def *ast.ObjectDefinition) (*Object, error) {
+	interfaces, err := b.makeImplementedInterfaces(def)
+	if err != nil {
+		return nil, err
+	}
+	return NewObject(ObjectConfig{
+		Name:       definitionName(def),
+		Interfaces: interfaces,
+		Fields:     FieldConfigMap{},
+	}), nil
+}
+
+func (b *astSchemaBuilder) makeImplementedInterfaces(def *ast.ObjectDefinition) ([]*Interface, error) {
+	interfaces := []*Interface{}
+	for _, namedAST := range def.Interfaces {
+		if _, ok := b.typeDefs[namedTypeName(namedAST)].(*ast.ObjectDefinition); ok {
+			return nil, fmt.Errorf(`%v may only implement Interface types, it cannot implement: %v.`, definitionName(def), namedTypeName(namedAST))
+		}
+		ttype, err := b.produceTypeDef(namedAST)
+		if err != nil {
+			return nil, err
+		}
+		iface, ok := ttype.(*Interface)
+		if !ok {
+			return nil, fmt.Errorf(`%v may only implement Interface types, it cannot implement: %v.`, definitionName(def), ttype)
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces, nil
+}
+
+func (b *astSchemaBuilder) makeUnionDef(def *ast.UnionDefinition) (*Union, error) {
+	types := []*Object{}
+	for _, namedAST := range def.Types {
+		if _, ok := b.typeDefs[namedTypeName(namedAST)].(*ast.UnionDefinition); ok {
+			return nil, fmt.Errorf(`%v may only contain Object types, it cannot contain: %v.`, definitionName(def), namedTypeName(namedAST))
+		}
+		ttype, err := b.produceTypeDef(namedAST)
+		if err != nil {
+			return nil, err
+		}
+		objectType, ok := ttype.(*Object)
+		if !ok {
+			return nil, fmt.Errorf(`%v may only contain Object types, it cannot contain: %v.`, definitionName(def), ttype)
+		}
+		types = append(types, objectType)
+	}
+	// Without a bound ResolveType, the type of a value is found with the
+	// IsTypeOf functions of the possible types.
+	var union *Union
+	union = NewUnion(UnionConfig{
+		Name:  definitionName(def),
+		Types: types,
+		ResolveType: func(value interface{}, info ResolveInfo) *Object {
+			return getTypeOf(value, info, union)
+		},
+	})
+	return union, nil
+}
+
+func makeEnumDef(def *ast.EnumDefinition) *Enum {
+	values := EnumValueConfigMap{}
+	for _, value := range def.Values {
+		if value.Name != nil {
+			values[value.Name.Value] = &EnumValueConfig{
+				DeprecationReason: deprecationReason(value.Directives),
+			}
+		}
+	}
+	return NewEnum(EnumConfig{
+		Name:   definitionName(def),
+		Values: values,
+	})
+}
+
+// Custom scalars pass values through as they are, unless a ScalarConfig is
+// bound to them with BindResolvers.
+func makeScalarDef(def *ast.ScalarDefinition) *Scalar {
+	return NewScalar(ScalarConfig{
+		Name: definitionName(def),
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+		ParseValue: func(value interface{}) interface{} {
+			return value
+		},
+		ParseLiteral: literalValue,
+	})
+}
+
+// literalValue returns the Go value of a constant value AST.
+func literalValue(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.IntValue:
+		if intValue, err := strconv.Atoi(valueAST.Value); err == nil {
+			return intValue
+		}
+	case *ast.FloatValue:
+		if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return floatValue
+		}
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	case *ast.ListValue:
+		values := []interface{}{}
+		for _, itemAST := range valueAST.Values {
+			values = append(values, literalValue(itemAST))
+		}
+		return values
+	case *ast.ObjectValue:
+		values := map[string]interface{}{}
+		for _, field := range valueAST.Fields {
+			if field.Name != nil {
+				values[field.Name.Value] = literalValue(field.Value)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func (b *astSchemaBuilder) makeFields(extensions []*ast.TypeExtensionDefinition) error {
+	for _, typeName := range b.typeNames {
+		switch def := b.typeDefs[typeName].(type) {
+		case *ast.ObjectDefinition:
+			err := b.addFieldConfigs(b.typeMap[typeName].(*Object), typeName, def.Fields)
+			if err != nil {
+				return err
+			}
+		case *ast.InterfaceDefinition:
+			err := b.addFieldConfigs(b.typeMap[typeName].(*Interface), typeName, def.Fields)
+			if err != nil {
+				return err
+			}
+		case *ast.InputObjectDefinition:
+			err := b.defineInputObjectFields(b.typeMap[typeName].(*InputObject), def)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, extension := range extensions {
+		if extension.Definition == nil {
+			continue
+		}
+		typeName := definitionName(extension.Definition)
+		objectType, ok := b.typeMap[typeName].(*Object)
+		if !ok {
+			return fmt.Errorf(`Cannot extend type "%v" because it is not an object type defined in the document.`, typeName)
+		}
+		interfaces, err := b.makeImplementedInterfaces(extension.Definition)
+		if err != nil {
+			return err
+		}
+		if len(interfaces) > 0 {
+			objectType.typeConfig.Interfaces = append(objectType.GetInterfaces(), interfaces...)
+			objectType.interfaces = nil
+			for _, iface := range interfaces {
+				iface.implementations = append(iface.implementations, objectType)
+			}
+		}
+		err = b.addFieldConfigs(objectType, typeName, extension.Definition.Fields)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fieldConfigAdder interface {
+	AddFieldConfig(fieldName string, fieldConfig *FieldConfig)
+}
+
+func (b *astSchemaBuilder) addFieldConfigs(ttype fieldConfigAdder, typeName string, fieldDefs []*ast.FieldDefinition) error {
+	for _, fieldDef := range fieldDefs {
+		if fieldDef.Name == nil {
+			continue
+		}
+		fieldType, err := b.produceTypeDef(fieldDef.Type)
+		if err != nil {
+			return err
+		}
+		if !IsOutputType(fieldType) {
+			return fmt.Errorf(`%v.%v field type must be Output Type but got: %v.`, typeName, fieldDef.Name.Value, fieldType)
+		}
+		args, err := b.makeInputValues(typeName+"."+fieldDef.Name.Value, fieldDef.Arguments)
+		if err != nil {
+			return err
+		}
+		fieldConfig := &FieldConfig{
+			Type:              fieldType,
+			Args:              FieldConfigArgument{},
+			DeprecationReason: deprecationReason(fieldDef.Directives),
+		}
+		for name, arg := range args {
+			fieldConfig.Args[name] = &ArgumentConfig{
+				Type:              arg.Type,
+				DefaultValue:      arg.DefaultValue,
+				DeprecationReason: arg.DeprecationReason,
+			}
+		}
+		ttype.AddFieldConfig(fieldDef.Name.Value, fieldConfig)
+	}
+	return nil
+}
+
+func (b *astSchemaBuilder) defineInputObjectFields(inputObject *InputObject, def *ast.InputObjectDefinition) error {
+	fields, err := b.makeInputValues(inputObject.Name, def.Fields)
+	if err != nil {
+		return err
+	}
+	inputObject.typeConfig.Fields = fields
+	inputObject.fields = inputObject.defineFieldMap()
+	return inputObject.GetError()
+}
+
+func (b *astSchemaBuilder) makeInputValues(parentName string, valueDefs []*ast.InputValueDefinition) (InputObjectConfigFieldMap, error) {
+	values := InputObjectConfigFieldMap{}
+	for _, valueDef := range valueDefs {
+		if valueDef.Name == nil {
+			continue
+		}
+		ttype, err := b.produceTypeDef(valueDef.Type)
+		if err != nil {
+			return nil, err
+		}
+		if !IsInputType(ttype) {
+			return nil, fmt.Errorf(`%v.%v type must be Input Type but got: %v.`, parentName, valueDef.Name.Value, ttype)
+		}
+		value := &InputObjectFieldConfig{
+			Type:              ttype,
+			DeprecationReason: deprecationReason(valueDef.Directives),
+		}
+		if valueDef.DefaultValue != nil {
+			value.DefaultValue = valueFromAST(valueDef.DefaultValue, ttype, nil)
+		}
+		values[valueDef.Name.Value] = value
+	}
+	return values, nil
+}
+
+// deprecationReason returns the reason of the @deprecated directive among the
+// directives, which is empty when there is none.
+func deprecationReason(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != DeprecatedDirective.Name {
+			continue
+		}
+		args, _ := getArgumentValues(DeprecatedDirective.Args, directive.Arguments, nil)
+		if reason, ok := args["reason"].(string); ok {
+			return reason
+		}
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+// produceTypeDef returns the type referenced by a type AST, wrapped in the
+// lists and non-nulls of the AST.
+func (b *astSchemaBuilder) produceTypeDef(typeAST ast.Type) (Type, error) {
+	switch typeAST := typeAST.(type) {
+	case *ast.List:
+		innerType, err := b.produceTypeDef(typeAST.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewList(innerType), nil
+	case *ast.NonNull:
+		innerType, err := b.produceTypeDef(typeAST.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewNonNull(innerType), nil
+	case *ast.Named:
+		typeName := namedTypeName(typeAST)
+		ttype, ok := b.typeMap[typeName]
+		if !ok {
+			return nil, fmt.Errorf(`Type "%v" not found in document.`, typeName)
+		}
+		return ttype, nil
+	}
+	return nil, errors.New("Must be a named type, a list or a non-null type.")
+}
+
+/**
+ * ResolverMap binds Go functions to the types and fields of a schema, most
+ * usefully one built from type definitions with BuildASTSchema.
+ *
+ * Keys are either "Type.field" names, mapped to a FieldResolveFn, a
+ * FieldResolveFnWithError or a FieldSubscribeFn, or type names, mapped to the
+ * ResolveTypeFn of an interface or union, the IsTypeOfFn of an object or the
+ * ScalarConfig of a custom scalar.
+ *
+ * Example:
+ *
+ *     err := BindResolvers(schema, ResolverMap{
+ *       "Query.hero": func(p GQLFRParams) interface{} {
+ *         return GetHero(p.Args["episode"])
+ *       },
+ *       "Character": func(value interface{}, info ResolveInfo) *Object {
+ *         ...
+ *       },
+ *     })
+ */
+type ResolverMap map[string]interface{}
+
+// BindResolvers attaches the functions of the resolver map to the schema. It
+// must be called before the schema is used to execute requests.
+func BindResolvers(schema Schema, resolvers ResolverMap) error {
+	for key, resolver := range resolvers {
+		typeName, fieldName := key, ""
+		if i := strings.Index(key, "."); i >= 0 {
+			typeName, fieldName = key[:i], key[i+1:]
+		}
+		ttype := schema.GetType(typeName)
+		if ttype == nil {
+			return fmt.Errorf(`Resolver "%v" refers to unknown type "%v".`, key, typeName)
+		}
+		var err error
+		if fieldName != "" {
+			err = bindFieldResolver(ttype, fieldName, key, resolver)
+		} else {
+			err = bindTypeResolver(ttype, key, resolver)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindFieldResolver(ttype Type, fieldName string, key string, resolver interface{}) error {
+	objectType, ok := ttype.(*Object)
+	if !ok {
+		return fmt.Errorf(`Resolver "%v" must refer to a field of an Object type.`, key)
+	}
+	objectType.mu.Lock()
+	defer objectType.mu.Unlock()
+	fieldConfig, ok := objectType.typeConfig.Fields[fieldName]
+	if !ok || fieldConfig == nil {
+		return fmt.Errorf(`Resolver "%v" refers to unknown field "%v" of type "%v".`, key, fieldName, objectType)
+	}
+	switch resolver := resolver.(type) {
+	case FieldResolveFn:
+		fieldConfig.Resolve, fieldConfig.ResolveWithError = resolver, nil
+	case func(p GQLFRParams) interface{}:
+		fieldConfig.Resolve, fieldConfig.ResolveWithError = resolver, nil
+	case FieldResolveFnWithError:
+		fieldConfig.Resolve, fieldConfig.ResolveWithError = nil, resolver
+	case func(p GQLFRParams) (interface{}, error):
+		fieldConfig.Resolve, fieldConfig.ResolveWithError = nil, resolver
+	case FieldSubscribeFn:
+		fieldConfig.Subscribe = resolver
+	case func(p GQLFRParams) (<-chan interface{}, error):
+		fieldConfig.Subscribe = resolver
+	default:
+		return fmt.Errorf(`Resolver "%v" must be a resolve or subscribe function but got: %T.`, key, resolver)
+	}
+	// the field definitions are defined again with the new functions
+	objectType.fields = nil
+	return nil
+}
+
+func bindTypeResolver(ttype Type, key string, resolver interface{}) error {
+	switch ttype := ttype.(type) {
+	case *Interface, *Union:
+		var resolveType ResolveTypeFn
+		switch resolver := resolver.(type) {
+		case ResolveTypeFn:
+			resolveType = resolver
+		case func(value interface{}, info ResolveInfo) *Object:
+			resolveType = resolver
+		default:
+			return fmt.Errorf(`Resolver "%v" must be a ResolveTypeFn but got: %T.`, key, resolver)
+		}
+		if iface, ok := ttype.(*Interface); ok {
+			iface.ResolveType = resolveType
+		} else {
+			ttype.(*Union).ResolveType = resolveType
+		}
+	case *Object:
+		switch resolver := resolver.(type) {
+		case IsTypeOfFn:
+			ttype.IsTypeOf = resolver
+		case func(value interface{}, info ResolveInfo) bool:
+			ttype.IsTypeOf = resolver
+		default:
+			return fmt.Errorf(`Resolver "%v" must be an IsTypeOfFn but got: %T.`, key, resolver)
+		}
+	case *Scalar:
+		if ttype == String || ttype == Int || ttype == Float || ttype == Boolean || ttype == ID {
+			return fmt.Errorf(`Resolver "%v" cannot be bound to the built-in scalar "%v".`, key, ttype)
+		}
+		config, ok := resolver.(ScalarConfig)
+		if !ok {
+			return fmt.Errorf(`Resolver "%v" must be a ScalarConfig but got: %T.`, key, resolver)
+		}
+		config.Name = ttype.Name
+		scalar := NewScalar(config)
+		if scalar.GetError() != nil {
+			return scalar.GetError()
+		}
+		ttype.Description = scalar.Description
+		ttype.scalarConfig = scalar.scalarConfig
+	default:
+		return fmt.Errorf(`Resolver "%v" cannot be bound to type "%v".`, key, ttype)
+	}
+	return nil
+}
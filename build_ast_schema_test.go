@@ -0,0 +1,309 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+const buildASTSchemaTestSDL = `
+  type Query {
+    hero(episode: Episode = NEWHOPE): Character
+    search(filter: SearchFilter): [SearchResult]
+    echo(value: Upper): Upper
+  }
+
+  type Mutation {
+    rename(name: String!): Human
+  }
+
+  interface Character {
+    name: String
+    friends: [Character]
+  }
+
+  type Human implements Character {
+    name: String
+    friends: [Character]
+  }
+
+  type Droid implements Character {
+    name: String
+    friends: [Character]
+  }
+
+  extend type Droid {
+    primaryFunction: String
+  }
+
+  union SearchResult = Human | Droid
+
+  enum Episode { NEWHOPE, EMPIRE, JEDI }
+
+  scalar Upper
+
+  input SearchFilter {
+    name: String
+    or: [SearchFilter]
+  }
+`
+
+type sdlCharacter struct {
+	Name            string         `json:"name"`
+	Friends         []sdlCharacter `json:"friends"`
+	PrimaryFunction string         `json:"primaryFunction"`
+	IsDroid         bool
+}
+
+func buildTestSDLSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, buildASTSchemaTestSDL), "Query", "Mutation")
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return schema
+}
+
+func bindTestSDLResolvers(t *testing.T, schema graphql.Schema, receivedArgs map[string]interface{}) {
+	r2d2 := sdlCharacter{Name: "R2-D2", PrimaryFunction: "Astromech", IsDroid: true}
+	luke := sdlCharacter{Name: "Luke Skywalker", Friends: []sdlCharacter{r2d2}}
+	isDroid := func(value interface{}) bool {
+		character, ok := value.(sdlCharacter)
+		return ok && character.IsDroid
+	}
+	err := graphql.BindResolvers(schema, graphql.ResolverMap{
+		"Query.hero": func(p graphql.GQLFRParams) interface{} {
+			receivedArgs["episode"] = p.Args["episode"]
+			if p.Args["episode"] == "EMPIRE" {
+				return luke
+			}
+			return r2d2
+		},
+		"Query.search": func(p graphql.GQLFRParams) (interface{}, error) {
+			receivedArgs["filter"] = p.Args["filter"]
+			return []interface{}{luke, r2d2}, nil
+		},
+		"Query.echo": func(p graphql.GQLFRParams) interface{} {
+			return p.Args["value"]
+		},
+		"Character": func(value interface{}, info graphql.ResolveInfo) *graphql.Object {
+			if isDroid(value) {
+				return schema.GetType("Droid").(*graphql.Object)
+			}
+			return schema.GetType("Human").(*graphql.Object)
+		},
+		"Human": func(value interface{}, info graphql.ResolveInfo) bool {
+			return !isDroid(value)
+		},
+		"Droid": func(value interface{}, info graphql.ResolveInfo) bool {
+			return isDroid(value)
+		},
+		"Upper": graphql.ScalarConfig{
+			Serialize: func(value interface{}) interface{} {
+				return value.(string) + "!"
+			},
+			ParseValue: func(value interface{}) interface{} {
+				return value
+			},
+			ParseLiteral: func(valueAST ast.Value) interface{} {
+				return valueAST.GetValue()
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error binding resolvers %v", err.Error())
+	}
+}
+
+func TestBuildASTSchema_ExecutesWithBoundResolvers(t *testing.T) {
+	schema := buildTestSDLSchema(t)
+	receivedArgs := map[string]interface{}{}
+	bindTestSDLResolvers(t, schema, receivedArgs)
+
+	query := `
+      {
+        droid: hero { name ... on Droid { primaryFunction } }
+        human: hero(episode: EMPIRE) { name friends { name } }
+        search(filter: {name: "R2", or: [{name: "Luke"}]}) {
+          ... on Human { name }
+          ... on Droid { primaryFunction }
+        }
+        echo(value: "hello")
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"droid": map[string]interface{}{
+				"name":            "R2-D2",
+				"primaryFunction": "Astromech",
+			},
+			"human": map[string]interface{}{
+				"name": "Luke Skywalker",
+				"friends": []interface{}{
+					map[string]interface{}{
+						"name": "R2-D2",
+					},
+				},
+			},
+			"search": []interface{}{
+				map[string]interface{}{
+					"name": "Luke Skywalker",
+				},
+				map[string]interface{}{
+					"primaryFunction": "Astromech",
+				},
+			},
+			"echo": "hello!",
+		},
+	}
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	}))
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	expectedArgs := map[string]interface{}{
+		"episode": "EMPIRE",
+		"filter": map[string]interface{}{
+			"name": "R2",
+			"or": []interface{}{
+				map[string]interface{}{"name": "Luke"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expectedArgs, receivedArgs) {
+		t.Fatalf("Unexpected args, Diff: %v", testutil.Diff(expectedArgs, receivedArgs))
+	}
+}
+
+func TestBuildASTSchema_AppliesDefaultValuesAndRootTypes(t *testing.T) {
+	schema := buildTestSDLSchema(t)
+	receivedArgs := map[string]interface{}{}
+	bindTestSDLResolvers(t, schema, receivedArgs)
+
+	result := graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ hero { name } }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if receivedArgs["episode"] != "NEWHOPE" {
+		t.Fatalf("Unexpected default episode: %v", receivedArgs["episode"])
+	}
+	if schema.GetQueryType().Name != "Query" || schema.GetMutationType().Name != "Mutation" {
+		t.Fatalf("Unexpected root types: %v, %v", schema.GetQueryType(), schema.GetMutationType())
+	}
+	if _, ok := schema.GetType("Droid").(*graphql.Object).GetFields()["primaryFunction"]; !ok {
+		t.Fatalf("expected the extension field primaryFunction on Droid")
+	}
+	possibleTypes := []string{}
+	for _, possibleType := range schema.GetType("Character").(*graphql.Interface).GetPossibleTypes() {
+		possibleTypes = append(possibleTypes, possibleType.Name)
+	}
+	if expected := []string{"Human", "Droid"}; !reflect.DeepEqual(expected, possibleTypes) {
+		t.Fatalf("Unexpected possible types, Diff: %v", testutil.Diff(expected, possibleTypes))
+	}
+}
+
+func TestBuildASTSchema_BuildsDeprecations(t *testing.T) {
+	sdl := `
+enum Episode {
+  EMPIRE
+  JEDI @deprecated(reason: "Use EMPIRE.")
+  NEWHOPE @deprecated
+}
+
+type Query {
+  hero(episode: Episode, first: Int = 1 @deprecated(reason: "Use episode.")): String
+  heroes(filter: SearchFilter): [String] @deprecated
+  search(filter: SearchFilter): [String]
+}
+
+input SearchFilter {
+  name: String @deprecated(reason: "Use query.")
+  query: String!
+}
+`
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, sdl), "Query", "")
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	expectPrinted(t, sdl, printForTest(t, schema, err))
+
+	fields := schema.GetQueryType().GetFields()
+	if reason := fields["heroes"].DeprecationReason; reason != graphql.DefaultDeprecationReason {
+		t.Fatalf("Unexpected deprecation reason: %v", reason)
+	}
+	if reason := fields["hero"].Args[1].DeprecationReason; reason != "Use episode." {
+		t.Fatalf("Unexpected deprecation reason: %v", reason)
+	}
+}
+
+func TestBuildASTSchema_ReportsInvalidDocuments(t *testing.T) {
+	tests := []struct {
+		sdl           string
+		queryType     string
+		mutationType  string
+		expectedError string
+	}{
+		{`type Query { a: String }`, "", "",
+			`Must provide a queryTypeName.`},
+		{`type Hello { a: String }`, "Query", "",
+			`Specified query type "Query" not found in document.`},
+		{`type Query { a: String }`, "Query", "Mutation",
+			`Specified mutation type "Mutation" not found in document.`},
+		{`type Query { a: Bar }`, "Query", "",
+			`Type "Bar" not found in document.`},
+		{`type Query { a: String } type Query { b: String }`, "Query", "",
+			`Type "Query" was defined more than once.`},
+		{`type Query { a: String } type String { b: String }`, "Query", "",
+			`Type "String" is a built-in type and cannot be redefined.`},
+		{`type Query { a(in: Query): String }`, "Query", "",
+			`Query.a.in type must be Input Type but got: Query.`},
+		{`type Query { a: In } input In { b: String }`, "Query", "",
+			`Query.a field type must be Output Type but got: In.`},
+		{`type Query implements Query { a: String }`, "Query", "",
+			`Query may only implement Interface types, it cannot implement: Query.`},
+		{`type Query { a: String } extend type Missing { b: String }`, "Query", "",
+			`Cannot extend type "Missing" because it is not an object type defined in the document.`},
+		{`type Query { a(in: String! @deprecated): String }`, "Query", "",
+			`Query.a(in:) required argument cannot be deprecated.`},
+		{`type Query { a(in: In): String } input In { b: Int! @deprecated }`, "Query", "",
+			`In.b required input field cannot be deprecated.`},
+	}
+	for _, test := range tests {
+		_, err := graphql.BuildASTSchema(testutil.TestParse(t, test.sdl), test.queryType, test.mutationType)
+		if err == nil || err.Error() != test.expectedError {
+			t.Fatalf("Unexpected error for %v, expected %v, got %v", test.sdl, test.expectedError, err)
+		}
+	}
+}
+
+func TestBindResolvers_ReportsInvalidResolvers(t *testing.T) {
+	schema := buildTestSDLSchema(t)
+	tests := []struct {
+		resolvers     graphql.ResolverMap
+		expectedError string
+	}{
+		{graphql.ResolverMap{"Missing.field": func(p graphql.GQLFRParams) interface{} { return nil }},
+			`Resolver "Missing.field" refers to unknown type "Missing".`},
+		{graphql.ResolverMap{"Query.missing": func(p graphql.GQLFRParams) interface{} { return nil }},
+			`Resolver "Query.missing" refers to unknown field "missing" of type "Query".`},
+		{graphql.ResolverMap{"Character.name": func(p graphql.GQLFRParams) interface{} { return nil }},
+			`Resolver "Character.name" must refer to a field of an Object type.`},
+		{graphql.ResolverMap{"Query.hero": "hero"},
+			`Resolver "Query.hero" must be a resolve or subscribe function but got: string.`},
+		{graphql.ResolverMap{"String": graphql.ScalarConfig{}},
+			`Resolver "String" cannot be bound to the built-in scalar "String".`},
+	}
+	for _, test := range tests {
+		err := graphql.BindResolvers(schema, test.resolvers)
+		if err == nil || err.Error() != test.expectedError {
+			t.Fatalf("Unexpected error, expected %v, got %v", test.expectedError, err)
+		}
+	}
+}
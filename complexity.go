@@ -0,0 +1,283 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+/**
+ * OperationComplexity holds the measures of an operation computed before its
+ * execution: the depth of its deepest field, root fields having a depth of 1,
+ * and its complexity, the sum of the complexities of its root fields.
+ *
+ * The complexity of a field is its cost plus the complexity of its selection
+ * set times its multiplier, see FieldComplexityFn.
+ */
+type OperationComplexity struct {
+	Depth      int `json:"depth"`
+	Complexity int `json:"complexity"`
+}
+
+/**
+ * Computes the depth and complexity of the operation of the params, without
+ * resolving any field. Fields selected on an abstract type are measured for
+ * each of its possible types, and the most complex one is counted.
+ *
+ * Complexities saturate at the largest int instead of overflowing. An error is
+ * returned when the Cost or Multiplier of a field is negative.
+ */
+func AnalyzeComplexity(p ExecuteParams) (OperationComplexity, error) {
+	exeContext, err := buildExecutionContext(BuildExecutionCtxParams{
+		Schema:        p.Schema,
+		Root:          p.Root,
+		AST:           p.AST,
+		OperationName: p.OperationName,
+		Args:          p.Args,
+		Context:       p.Context,
+	})
+	if err != nil {
+		return OperationComplexity{}, err
+	}
+	operationType, err := getOperationRootType(p.Schema, exeContext.Operation)
+	if err != nil {
+		return OperationComplexity{}, err
+	}
+	analyzer := newComplexityAnalyzer(exeContext, 0, 0)
+	complexity := analyzer.analyze(operationType)
+	if analyzer.err != nil {
+		return OperationComplexity{}, analyzer.err
+	}
+	return complexity, nil
+}
+
+// checkComplexity returns a located error when the operation to execute is
+// deeper or more complex than the limits, which are ignored when 0, and the
+// complexity of the operation otherwise. The analysis stops at the first
+// limit exceeded.
+func checkComplexity(eCtx *ExecutionContext, maxDepth int, maxComplexity int) (*OperationComplexity, error) {
+	if maxDepth <= 0 && maxComplexity <= 0 {
+		return nil, nil
+	}
+	operationType, err := getOperationRootType(eCtx.Schema, eCtx.Operation)
+	if err != nil {
+		// reported by the execution of the operation
+		return nil, nil
+	}
+	analyzer := newComplexityAnalyzer(eCtx, maxDepth, maxComplexity)
+	complexity := analyzer.analyze(operationType)
+	if analyzer.err != nil {
+		return nil, analyzer.err
+	}
+	if analyzer.tooDeepField != nil {
+		return nil, NewLocatedError(
+			fmt.Sprintf(`Field "%v" exceeds the maximum depth of %v.`, analyzer.tooDeepField.Name.Value, maxDepth),
+			[]ast.Node{analyzer.tooDeepField},
+		)
+	}
+	if analyzer.tooComplex {
+		nodes := []ast.Node{}
+		if operation, ok := eCtx.Operation.(*ast.OperationDefinition); ok {
+			nodes = append(nodes, operation)
+		}
+		return nil, NewLocatedError(
+			fmt.Sprintf(`Operation has a complexity of at least %v, which exceeds the maximum complexity of %v.`,
+				complexity.Complexity, maxComplexity),
+			nodes,
+		)
+	}
+	return &complexity, nil
+}
+
+type complexityAnalyzer struct {
+	eCtx *ExecutionContext
+
+	// maxDepth and maxComplexity stop the analysis once exceeded, the first
+	// field deeper than maxDepth being recorded in tooDeepField.
+	maxDepth      int
+	maxComplexity int
+	tooDeepField  *ast.Field
+	tooComplex    bool
+	// err is the error of a field with a negative cost or multiplier, which
+	// stops the analysis as well.
+	err error
+
+	// measures holds the measure of the selection sets already measured on a
+	// type at a depth, since the selection sets of the fields of an abstract
+	// type are measured again for each of its possible types.
+	measures map[complexityKey]complexityMeasure
+}
+
+type complexityKey struct {
+	ttype         Named
+	selectionSets string
+	depth         int
+}
+
+type complexityMeasure struct {
+	complexity int
+	depth      int
+}
+
+func newComplexityAnalyzer(eCtx *ExecutionContext, maxDepth int, maxComplexity int) *complexityAnalyzer {
+	return &complexityAnalyzer{
+		eCtx:          eCtx,
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+		measures:      map[complexityKey]complexityMeasure{},
+	}
+}
+
+func (a *complexityAnalyzer) stopped() bool {
+	return a.err != nil || a.tooDeepField != nil || a.tooComplex
+}
+
+// exceeds records whether the complexity, which is part of the complexity of
+// the operation when counted, exceeds the maximum complexity.
+func (a *complexityAnalyzer) exceeds(complexity int, counted bool) bool {
+	if counted && a.maxComplexity > 0 && complexity > a.maxComplexity {
+		a.tooComplex = true
+	}
+	return a.tooComplex
+}
+
+func (a *complexityAnalyzer) analyze(operationType *Object) OperationComplexity {
+	complexity, depth := a.measure(operationType, []*ast.SelectionSet{a.eCtx.Operation.GetSelectionSet()}, 1, true)
+	return OperationComplexity{
+		Depth:      depth,
+		Complexity: complexity,
+	}
+}
+
+// measure returns the complexity of the selection sets selected on the type,
+// and the depth of their deepest field, the fields of the sets having the
+// given depth. The complexity is counted when the product of the multipliers
+// of the parent fields is at least 1, so that it is a lower bound of the
+// complexity of the operation.
+func (a *complexityAnalyzer) measure(ttype Named, selectionSets []*ast.SelectionSet, depth int, counted bool) (int, int) {
+	if a.stopped() {
+		return 0, 0
+	}
+	key := complexityKey{ttype: ttype, depth: depth}
+	for _, selectionSet := range selectionSets {
+		key.selectionSets += fmt.Sprintf("%p,", selectionSet)
+	}
+	if measure, ok := a.measures[key]; ok {
+		a.exceeds(measure.complexity, counted)
+		return measure.complexity, measure.depth
+	}
+
+	complexity, deepest := 0, 0
+	switch ttype := ttype.(type) {
+	case *Object:
+		complexity, deepest = a.measureObject(ttype, selectionSets, depth, counted)
+	case Abstract:
+		for _, possibleType := range ttype.GetPossibleTypes() {
+			possibleComplexity, possibleDepth := a.measure(possibleType, selectionSets, depth, counted)
+			if possibleComplexity > complexity {
+				complexity = possibleComplexity
+			}
+			if possibleDepth > deepest {
+				deepest = possibleDepth
+			}
+		}
+	}
+	if !a.stopped() {
+		a.measures[key] = complexityMeasure{complexity: complexity, depth: deepest}
+	}
+	return complexity, deepest
+}
+
+func (a *complexityAnalyzer) measureObject(objectType *Object, selectionSets []*ast.SelectionSet, depth int, counted bool) (int, int) {
+	fields := NewFieldASTsMap()
+	visitedFragmentNames := map[string]bool{}
+	for _, selectionSet := range selectionSets {
+		fields = collectFields(CollectFieldsParams{
+			ExeContext:           a.eCtx,
+			OperationType:        objectType,
+			SelectionSet:         selectionSet,
+			Fields:               fields,
+			VisitedFragmentNames: visitedFragmentNames,
+		})
+	}
+
+	complexity, maxDepth := 0, 0
+	for _, responseName := range fields.Names {
+		fieldASTs := fields.Fields[responseName]
+		fieldAST := fieldASTs[0]
+		fieldName := ""
+		if fieldAST.Name != nil {
+			fieldName = fieldAST.Name.Value
+		}
+		fieldDef := getFieldDef(a.eCtx.Schema, objectType, fieldName)
+		if fieldDef == nil {
+			continue
+		}
+		if a.maxDepth > 0 && depth > a.maxDepth {
+			a.tooDeepField = fieldAST
+			return complexity, depth
+		}
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+
+		args, _ := getArgumentValues(fieldDef.Args, fieldAST.Arguments, a.eCtx.VariableValues)
+		cost, multiplier := 1, 1
+		if fieldDef.Cost != nil {
+			cost = fieldDef.Cost(args)
+		}
+		if fieldDef.Multiplier != nil {
+			multiplier = fieldDef.Multiplier(args)
+		}
+		if cost < 0 || multiplier < 0 {
+			a.err = NewLocatedError(
+				fmt.Sprintf(`Field "%v" has a negative cost or multiplier.`, fieldName),
+				[]ast.Node{fieldAST},
+			)
+			return complexity, maxDepth
+		}
+
+		subSelectionSets := []*ast.SelectionSet{}
+		for _, fieldAST := range fieldASTs {
+			if fieldAST.SelectionSet != nil {
+				subSelectionSets = append(subSelectionSets, fieldAST.SelectionSet)
+			}
+		}
+		childComplexity := 0
+		if len(subSelectionSets) > 0 {
+			var childDepth int
+			childComplexity, childDepth = a.measure(GetNamed(fieldDef.Type), subSelectionSets, depth+1, counted && multiplier >= 1)
+			if childDepth > maxDepth {
+				maxDepth = childDepth
+			}
+			if a.stopped() {
+				return complexity, maxDepth
+			}
+		}
+
+		complexity = saturatedAdd(complexity, saturatedAdd(cost, saturatedMul(multiplier, childComplexity)))
+		if a.exceeds(complexity, counted) {
+			return complexity, maxDepth
+		}
+	}
+	return complexity, maxDepth
+}
+
+// maxInt is the largest int, unlike MaxInt which is the largest GraphQL Int.
+const maxInt = int(^uint(0) >> 1)
+
+// saturatedAdd and saturatedMul compute with non-negative integers, their
+// results being maxInt when they would overflow.
+func saturatedAdd(x int, y int) int {
+	if x > maxInt-y {
+		return maxInt
+	}
+	return x + y
+}
+
+func saturatedMul(x int, y int) int {
+	if x != 0 && y > maxInt/x {
+		return maxInt
+	}
+	return x * y
+}
@@ -0,0 +1,303 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func pageSize(args map[string]interface{}) int {
+	if first, ok := args["first"].(int); ok {
+		return first
+	}
+	return 10
+}
+
+func newComplexityTestSchema(t *testing.T, resolved *int) graphql.Schema {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldConfigMap{
+			"name": &graphql.FieldConfig{
+				Type: graphql.String,
+			},
+		},
+	})
+	pageArgs := graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{Type: graphql.Int},
+	}
+	resolveUsers := func(p graphql.GQLFRParams) interface{} {
+		*resolved++
+		return []interface{}{
+			map[string]interface{}{"name": "Luke"},
+		}
+	}
+	userType.AddFieldConfig("friends", &graphql.FieldConfig{
+		Type:       graphql.NewList(userType),
+		Args:       pageArgs,
+		Resolve:    resolveUsers,
+		Multiplier: pageSize,
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"users": &graphql.FieldConfig{
+					Type:       graphql.NewList(userType),
+					Args:       pageArgs,
+					Resolve:    resolveUsers,
+					Multiplier: pageSize,
+				},
+				"search": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						*resolved++
+						return "found"
+					},
+					Cost: func(args map[string]interface{}) int {
+						return 50
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return schema
+}
+
+func TestAnalyzeComplexity_MeasuresDepthAndComplexity(t *testing.T) {
+	resolved := 0
+	schema := newComplexityTestSchema(t, &resolved)
+	tests := []struct {
+		query    string
+		args     map[string]interface{}
+		expected graphql.OperationComplexity
+	}{
+		{`{ search }`, nil,
+			graphql.OperationComplexity{Depth: 1, Complexity: 50}},
+		{`{ users { name } }`, nil,
+			graphql.OperationComplexity{Depth: 2, Complexity: 1 + 10*1}},
+		{`query Q($n: Int) { users(first: $n) { name friends(first: 2) { name } } }`,
+			map[string]interface{}{"n": 5},
+			graphql.OperationComplexity{Depth: 3, Complexity: 1 + 5*(1+(1+2*1))}},
+		{`{ users(first: 3) { ...F ...F } } fragment F on User { name friends { name } }`, nil,
+			graphql.OperationComplexity{Depth: 3, Complexity: 1 + 3*(1+(1+10*1))}},
+		{`{ users(first: 3) { name friends @skip(if: true) { name } } }`, nil,
+			graphql.OperationComplexity{Depth: 2, Complexity: 1 + 3*1}},
+	}
+	for _, test := range tests {
+		complexity, err := graphql.AnalyzeComplexity(graphql.ExecuteParams{
+			Schema: schema,
+			AST:    testutil.TestParse(t, test.query),
+			Args:   test.args,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", test.query, err)
+		}
+		if !reflect.DeepEqual(test.expected, complexity) {
+			t.Fatalf("Unexpected complexity for %v, Diff: %v", test.query, testutil.Diff(test.expected, complexity))
+		}
+	}
+	if resolved != 0 {
+		t.Fatalf("Unexpected resolved fields during the analysis: %v", resolved)
+	}
+}
+
+func TestAnalyzeComplexity_CountsTheMostComplexPossibleType(t *testing.T) {
+	query := `{
+      hero {
+        name
+        friends {
+          name
+          ... on Human { homePlanet }
+        }
+      }
+    }`
+	complexity, err := graphql.AnalyzeComplexity(graphql.ExecuteParams{
+		Schema: testutil.StarWarsSchema,
+		AST:    testutil.TestParse(t, query),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := graphql.OperationComplexity{Depth: 3, Complexity: 1 + (1 + (1 + 2))}
+	if !reflect.DeepEqual(expected, complexity) {
+		t.Fatalf("Unexpected complexity, Diff: %v", testutil.Diff(expected, complexity))
+	}
+}
+
+func TestExecute_RejectsOperationsOverTheLimits(t *testing.T) {
+	resolved := 0
+	schema := newComplexityTestSchema(t, &resolved)
+	tests := []struct {
+		query         string
+		maxDepth      int
+		maxComplexity int
+		expected      *graphql.Result
+	}{
+		{
+			query: `{
+      users {
+        friends {
+          friends { name }
+        }
+      }
+    }`,
+			maxDepth: 3,
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{
+					gqlerrors.FormattedError{
+						Message: `Field "name" exceeds the maximum depth of 3.`,
+						Locations: []location.SourceLocation{
+							location.SourceLocation{Line: 4, Column: 21},
+						},
+					},
+				},
+			},
+		},
+		{
+			query:         `query Q { search users { name } }`,
+			maxComplexity: 60,
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{
+					gqlerrors.FormattedError{
+						Message: `Operation has a complexity of at least 61, which exceeds the maximum complexity of 60.`,
+						Locations: []location.SourceLocation{
+							location.SourceLocation{Line: 1, Column: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			query:         `{ search users(first: 9) { name } }`,
+			maxDepth:      2,
+			maxComplexity: 60,
+			expected: &graphql.Result{
+				Data: map[string]interface{}{
+					"search": "found",
+					"users": []interface{}{
+						map[string]interface{}{"name": "Luke"},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		resolved = 0
+		result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+			Schema:        schema,
+			RequestString: test.query,
+			MaxDepth:      test.maxDepth,
+			MaxComplexity: test.maxComplexity,
+		}))
+		if !reflect.DeepEqual(test.expected, result) {
+			t.Fatalf("Unexpected result for %v, Diff: %v", test.query, testutil.Diff(test.expected, result))
+		}
+		if test.expected.Data == nil && resolved != 0 {
+			t.Fatalf("Unexpected resolved fields for a rejected operation: %v", resolved)
+		}
+	}
+}
+
+func TestAnalyzeComplexity_SaturatesInsteadOfOverflowing(t *testing.T) {
+	resolved := 0
+	schema := newComplexityTestSchema(t, &resolved)
+	query := `{
+      users(first: 4000000000) {
+        friends(first: 4000000000) {
+          friends(first: 4000000000) { name }
+        }
+      }
+    }`
+	complexity, err := graphql.AnalyzeComplexity(graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, query),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := graphql.OperationComplexity{Depth: 4, Complexity: int(^uint(0) >> 1)}
+	if !reflect.DeepEqual(expected, complexity) {
+		t.Fatalf("Unexpected complexity, Diff: %v", testutil.Diff(expected, complexity))
+	}
+
+	result := graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		MaxComplexity: 1000,
+	})
+	if len(result.Errors) != 1 || result.Data != nil || resolved != 0 {
+		t.Fatalf("Expected the operation to be rejected, got %v", result)
+	}
+	if expected := "which exceeds the maximum complexity of 1000."; !strings.HasSuffix(result.Errors[0].Message, expected) {
+		t.Fatalf("Unexpected error: %v", result.Errors[0].Message)
+	}
+}
+
+func TestAnalyzeComplexity_RejectsNegativeCosts(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"refund": &graphql.FieldConfig{
+					Type: graphql.String,
+					Cost: func(args map[string]interface{}) int {
+						return -1000
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	expectedErr := `Field "refund" has a negative cost or multiplier.`
+	_, err = graphql.AnalyzeComplexity(graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `{ refund }`),
+	})
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result := graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ refund }`,
+		MaxComplexity: 10,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != expectedErr {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestAnalyzeComplexity_MeasuresNestedAbstractTypesOnce(t *testing.T) {
+	// each level of friends is measured for both Human and Droid, which
+	// takes exponential time unless the shared selection sets are measured once
+	query := "{ hero { " + strings.Repeat("friends { ", 40) + "name" + strings.Repeat(" }", 40) + " } }"
+	complexity, err := graphql.AnalyzeComplexity(graphql.ExecuteParams{
+		Schema: testutil.StarWarsSchema,
+		AST:    testutil.TestParse(t, query),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := graphql.OperationComplexity{Depth: 42, Complexity: 42}
+	if !reflect.DeepEqual(expected, complexity) {
+		t.Fatalf("Unexpected complexity, Diff: %v", testutil.Diff(expected, complexity))
+	}
+
+	result := graphql.Graphql(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: query,
+		MaxDepth:      10,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Field "friends" exceeds the maximum depth of 10.` {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
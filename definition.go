@@ -1,10 +1,13 @@
 package graphql
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
+	"sync"
 
 	"github.com/graphql-go/graphql/language/ast"
 )
@@ -293,6 +296,10 @@ type Object struct {
 	interfaces []*Interface
 	// Interim alternative to throwing an error during schema definition at run-time
 	err error
+
+	// Guards the lazily defined fields and interfaces, which may be read by
+	// concurrently resolving fields.
+	mu sync.Mutex
 }
 
 type IsTypeOfFn func(value interface{}, info ResolveInfo) bool
@@ -347,8 +354,10 @@ func (gt *Object) AddFieldConfig(fieldName string, fieldConfig *FieldConfig) {
 	if fieldName == "" || fieldConfig == nil {
 		return
 	}
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
 	gt.typeConfig.Fields[fieldName] = fieldConfig
-
+	gt.fields = nil
 }
 func (gt *Object) GetName() string {
 	return gt.Name
@@ -360,12 +369,22 @@ func (gt *Object) String() string {
 	return gt.Name
 }
 func (gt *Object) GetFields() FieldDefinitionMap {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	if gt.fields != nil {
+		return gt.fields
+	}
 	fields, err := defineFieldMap(gt, gt.typeConfig.Fields)
 	gt.err = err
 	gt.fields = fields
 	return gt.fields
 }
 func (gt *Object) GetInterfaces() []*Interface {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	if gt.interfaces != nil {
+		return gt.interfaces
+	}
 	var configInterfaces []*Interface
 	switch gt.typeConfig.Interfaces.(type) {
 	case InterfacesThunk:
@@ -448,16 +467,28 @@ func defineFieldMap(ttype Named, fields FieldConfigMap) (FieldDefinitionMap, err
 		if err != nil {
 			return resultFieldMap, err
 		}
+		err = invariant(
+			field.Resolve == nil || field.ResolveWithError == nil,
+			fmt.Sprintf(`%v.%v field must provide either Resolve or ResolveWithError, not both.`, ttype, fieldName),
+		)
+		if err != nil {
+			return resultFieldMap, err
+		}
 		fieldDef := &FieldDefinition{
 			Name:              fieldName,
 			Description:       field.Description,
 			Type:              field.Type,
 			Resolve:           field.Resolve,
+			ResolveWithError:  field.ResolveWithError,
+			Subscribe:         field.Subscribe,
+			Cost:              field.Cost,
+			Multiplier:        field.Multiplier,
 			DeprecationReason: field.DeprecationReason,
 		}
 
 		fieldDef.Args = []*Argument{}
-		for argName, arg := range field.Args {
+		for _, argName := range sortedArgumentNames(field.Args) {
+			arg := field.Args[argName]
 			err := assertValidName(argName)
 			if err != nil {
 				return resultFieldMap, err
@@ -476,11 +507,19 @@ func defineFieldMap(ttype Named, fields FieldConfigMap) (FieldDefinitionMap, err
 			if err != nil {
 				return resultFieldMap, err
 			}
+			err = invariant(
+				arg.DeprecationReason == "" || !isRequiredInput(arg.Type, arg.DefaultValue),
+				fmt.Sprintf(`%v.%v(%v:) required argument cannot be deprecated.`, ttype, fieldName, argName),
+			)
+			if err != nil {
+				return resultFieldMap, err
+			}
 			fieldArg := &Argument{
-				Name:         argName,
-				Description:  arg.Description,
-				Type:         arg.Type,
-				DefaultValue: arg.DefaultValue,
+				Name:              argName,
+				Description:       arg.Description,
+				Type:              arg.Type,
+				DefaultValue:      arg.DefaultValue,
+				DeprecationReason: arg.DeprecationReason,
 			}
 			fieldDef.Args = append(fieldDef.Args, fieldArg)
 		}
@@ -489,17 +528,50 @@ func defineFieldMap(ttype Named, fields FieldConfigMap) (FieldDefinitionMap, err
 	return resultFieldMap, nil
 }
 
+// sortedArgumentNames returns the argument names in a stable order, so that
+// introspection and validation report arguments deterministically.
+func sortedArgumentNames(args FieldConfigArgument) []string {
+	names := []string{}
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // TODO: clean up GQLFRParams fields
 type GQLFRParams struct {
 	Source interface{}
 	Args   map[string]interface{}
 	Info   ResolveInfo
 	Schema Schema
+
+	// Context is the context of the request being executed, carrying its
+	// deadline, cancellation signal and request-scoped values.
+	Context context.Context
 }
 
 // TODO: relook at FieldResolveFn params
 type FieldResolveFn func(p GQLFRParams) interface{}
 
+// FieldResolveFnWithError resolves a field like FieldResolveFn, but reports
+// failures by returning an error instead of panicking. A returned error nulls
+// the field, or its closest nullable parent for non-null fields, and is
+// reported with the locations of the field.
+type FieldResolveFnWithError func(p GQLFRParams) (interface{}, error)
+
+// FieldSubscribeFn creates the source event stream of a subscription root
+// field. Each value received from the stream is executed as the root value
+// of the subscription selection set, until the stream is closed or the
+// context of the subscription is done.
+type FieldSubscribeFn func(p GQLFRParams) (<-chan interface{}, error)
+
+// FieldComplexityFn computes a factor of the complexity of a field from its
+// argument values. The Cost of a field is its own complexity, 1 when not set,
+// and its Multiplier the number of times the complexity of its selection set
+// is counted, 1 when not set, such as the size of a requested page.
+type FieldComplexityFn func(args map[string]interface{}) int
+
 type ResolveInfo struct {
 	FieldName      string
 	FieldASTs      []*ast.Field
@@ -510,6 +582,30 @@ type ResolveInfo struct {
 	RootValue      interface{}
 	Operation      ast.Definition
 	VariableValues map[string]interface{}
+	Path           *ResponsePath
+}
+
+// ResponsePath is the path from the root of the response to the field being
+// resolved, made of response keys and, within lists, item indices.
+type ResponsePath struct {
+	Prev *ResponsePath
+	Key  interface{}
+}
+
+// WithKey returns the path extended with the given response key or index.
+func (p *ResponsePath) WithKey(key interface{}) *ResponsePath {
+	return &ResponsePath{
+		Prev: p,
+		Key:  key,
+	}
+}
+
+// AsArray returns the keys of the path, from the root of the response.
+func (p *ResponsePath) AsArray() []interface{} {
+	if p == nil {
+		return nil
+	}
+	return append(p.Prev.AsArray(), p.Key)
 }
 
 type FieldConfigMap map[string]*FieldConfig
@@ -519,6 +615,10 @@ type FieldConfig struct {
 	Type              Output              `json:"type"`
 	Args              FieldConfigArgument `json:"args"`
 	Resolve           FieldResolveFn
+	ResolveWithError  FieldResolveFnWithError
+	Subscribe         FieldSubscribeFn
+	Cost              FieldComplexityFn
+	Multiplier        FieldComplexityFn
 	DeprecationReason string `json:"deprecationReason"`
 	Description       string `json:"description"`
 }
@@ -526,19 +626,24 @@ type FieldConfig struct {
 type FieldConfigArgument map[string]*ArgumentConfig
 
 type ArgumentConfig struct {
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
 }
 
 type FieldDefinitionMap map[string]*FieldDefinition
 type FieldDefinition struct {
-	Name              string         `json:"name"`
-	Description       string         `json:"description"`
-	Type              Output         `json:"type"`
-	Args              []*Argument    `json:"args"`
-	Resolve           FieldResolveFn `json:"-"`
-	DeprecationReason string         `json:"deprecationReason"`
+	Name              string                  `json:"name"`
+	Description       string                  `json:"description"`
+	Type              Output                  `json:"type"`
+	Args              []*Argument             `json:"args"`
+	Resolve           FieldResolveFn          `json:"-"`
+	ResolveWithError  FieldResolveFnWithError `json:"-"`
+	Subscribe         FieldSubscribeFn        `json:"-"`
+	Cost              FieldComplexityFn       `json:"-"`
+	Multiplier        FieldComplexityFn       `json:"-"`
+	DeprecationReason string                  `json:"deprecationReason"`
 }
 
 type FieldArgument struct {
@@ -549,10 +654,11 @@ type FieldArgument struct {
 }
 
 type Argument struct {
-	Name         string      `json:"name"`
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Name              string      `json:"name"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
 }
 
 func (st *Argument) GetName() string {
@@ -569,6 +675,13 @@ func (st *Argument) GetError() error {
 	return nil
 }
 
+// isRequiredInput reports whether an argument or input field of the type and
+// default value must be provided, in which case it cannot be deprecated.
+func isRequiredInput(ttype Input, defaultValue interface{}) bool {
+	_, isNonNull := ttype.(*NonNull)
+	return isNonNull && isNullish(defaultValue)
+}
+
 /**
  * Interface Type Definition
  *
@@ -598,6 +711,8 @@ type Interface struct {
 	possibleTypes   map[string]bool
 
 	err error
+
+	mu sync.Mutex
 }
 type InterfaceConfig struct {
 	Name        string         `json:"name"`
@@ -633,7 +748,10 @@ func (it *Interface) AddFieldConfig(fieldName string, fieldConfig *FieldConfig)
 	if fieldName == "" || fieldConfig == nil {
 		return
 	}
+	it.mu.Lock()
+	defer it.mu.Unlock()
 	it.typeConfig.Fields[fieldName] = fieldConfig
+	it.fields = nil
 }
 func (it *Interface) GetName() string {
 	return it.Name
@@ -642,6 +760,11 @@ func (it *Interface) GetDescription() string {
 	return it.Description
 }
 func (it *Interface) GetFields() (fields FieldDefinitionMap) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.fields != nil {
+		return it.fields
+	}
 	it.fields, it.err = defineFieldMap(it, it.typeConfig.Fields)
 	return it.fields
 }
@@ -652,6 +775,8 @@ func (it *Interface) IsPossibleType(ttype *Object) bool {
 	if ttype == nil {
 		return false
 	}
+	it.mu.Lock()
+	defer it.mu.Unlock()
 	if len(it.possibleTypes) == 0 {
 		possibleTypes := map[string]bool{}
 		for _, possibleType := range it.GetPossibleTypes() {
@@ -726,6 +851,8 @@ type Union struct {
 	possibleTypes map[string]bool
 
 	err error
+
+	mu sync.Mutex
 }
 type UnionConfig struct {
 	Name        string    `json:"name"`
@@ -795,6 +922,8 @@ func (ut *Union) IsPossibleType(ttype *Object) bool {
 	if ttype == nil {
 		return false
 	}
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
 	if len(ut.possibleTypes) == 0 {
 		possibleTypes := map[string]bool{}
 		for _, possibleType := range ut.GetPossibleTypes() {
@@ -861,6 +990,8 @@ type Enum struct {
 	nameLookup   map[string]*EnumValueDefinition
 
 	err error
+
+	mu sync.Mutex
 }
 type EnumValueConfigMap map[string]*EnumValueConfig
 type EnumValueConfig struct {
@@ -977,6 +1108,8 @@ func (gt *Enum) GetError() error {
 	return gt.err
 }
 func (gt *Enum) getValueLookup() map[interface{}]*EnumValueDefinition {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
 	if len(gt.valuesLookup) > 0 {
 		return gt.valuesLookup
 	}
@@ -989,6 +1122,8 @@ func (gt *Enum) getValueLookup() map[interface{}]*EnumValueDefinition {
 }
 
 func (gt *Enum) getNameLookup() map[string]*EnumValueDefinition {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
 	if len(gt.nameLookup) > 0 {
 		return gt.nameLookup
 	}
@@ -1030,15 +1165,17 @@ type InputObject struct {
 	err error
 }
 type InputObjectFieldConfig struct {
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
 }
 type InputObjectField struct {
-	Name         string      `json:"name"`
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Name              string      `json:"name"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
 }
 
 func (st *InputObjectField) GetName() string {
@@ -1115,11 +1252,20 @@ func (gt *InputObject) defineFieldMap() InputObjectFieldMap {
 			gt.err = err
 			return resultFieldMap
 		}
+		err = invariant(
+			fieldConfig.DeprecationReason == "" || !isRequiredInput(fieldConfig.Type, fieldConfig.DefaultValue),
+			fmt.Sprintf(`%v.%v required input field cannot be deprecated.`, gt, fieldName),
+		)
+		if err != nil {
+			gt.err = err
+			return resultFieldMap
+		}
 		field := &InputObjectField{}
 		field.Name = fieldName
 		field.Type = fieldConfig.Type
 		field.Description = fieldConfig.Description
 		field.DefaultValue = fieldConfig.DefaultValue
+		field.DeprecationReason = fieldConfig.DeprecationReason
 		resultFieldMap[fieldName] = field
 	}
 	return resultFieldMap
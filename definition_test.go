@@ -458,6 +458,27 @@ func TestTypeSystem_DefinitionExample_ProhibitsNilTypeInUnions(t *testing.T) {
 		t.Fatalf(`expected %v , got: %v`, expected, ttype.GetError())
 	}
 }
+func TestTypeSystem_DefinitionExample_ProhibitsBothResolveAndResolveWithError(t *testing.T) {
+	ttype := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SomeObject",
+		Fields: graphql.FieldConfigMap{
+			"f": &graphql.FieldConfig{
+				Type: graphql.String,
+				Resolve: func(p graphql.GQLFRParams) interface{} {
+					return nil
+				},
+				ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+					return nil, nil
+				},
+			},
+		},
+	})
+	ttype.GetFields()
+	expected := `SomeObject.f field must provide either Resolve or ResolveWithError, not both.`
+	if ttype.GetError() == nil || ttype.GetError().Error() != expected {
+		t.Fatalf(`expected %v , got: %v`, expected, ttype.GetError())
+	}
+}
 func TestTypeSystem_DefinitionExample_DoesNotMutatePassedFieldDefinitions(t *testing.T) {
 	fields := graphql.FieldConfigMap{
 		"field1": &graphql.FieldConfig{
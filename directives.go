@@ -1,14 +1,96 @@
 package graphql
 
+// DirectiveLocation is a location of a document a directive may be used at.
+type DirectiveLocation string
+
+const (
+	// Operations
+	DirectiveLocationQuery              DirectiveLocation = "QUERY"
+	DirectiveLocationMutation           DirectiveLocation = "MUTATION"
+	DirectiveLocationSubscription       DirectiveLocation = "SUBSCRIPTION"
+	DirectiveLocationField              DirectiveLocation = "FIELD"
+	DirectiveLocationFragmentDefinition DirectiveLocation = "FRAGMENT_DEFINITION"
+	DirectiveLocationFragmentSpread     DirectiveLocation = "FRAGMENT_SPREAD"
+	DirectiveLocationInlineFragment     DirectiveLocation = "INLINE_FRAGMENT"
+	DirectiveLocationVariableDefinition DirectiveLocation = "VARIABLE_DEFINITION"
+
+	// Schema Definitions
+	DirectiveLocationSchema               DirectiveLocation = "SCHEMA"
+	DirectiveLocationScalar               DirectiveLocation = "SCALAR"
+	DirectiveLocationObject               DirectiveLocation = "OBJECT"
+	DirectiveLocationFieldDefinition      DirectiveLocation = "FIELD_DEFINITION"
+	DirectiveLocationArgumentDefinition   DirectiveLocation = "ARGUMENT_DEFINITION"
+	DirectiveLocationInterface            DirectiveLocation = "INTERFACE"
+	DirectiveLocationUnion                DirectiveLocation = "UNION"
+	DirectiveLocationEnum                 DirectiveLocation = "ENUM"
+	DirectiveLocationEnumValue            DirectiveLocation = "ENUM_VALUE"
+	DirectiveLocationInputObject          DirectiveLocation = "INPUT_OBJECT"
+	DirectiveLocationInputFieldDefinition DirectiveLocation = "INPUT_FIELD_DEFINITION"
+)
+
+// DirectiveLocations lists every DirectiveLocation, in the order of the spec.
+var DirectiveLocations = []DirectiveLocation{
+	DirectiveLocationQuery,
+	DirectiveLocationMutation,
+	DirectiveLocationSubscription,
+	DirectiveLocationField,
+	DirectiveLocationFragmentDefinition,
+	DirectiveLocationFragmentSpread,
+	DirectiveLocationInlineFragment,
+	DirectiveLocationVariableDefinition,
+	DirectiveLocationSchema,
+	DirectiveLocationScalar,
+	DirectiveLocationObject,
+	DirectiveLocationFieldDefinition,
+	DirectiveLocationArgumentDefinition,
+	DirectiveLocationInterface,
+	DirectiveLocationUnion,
+	DirectiveLocationEnum,
+	DirectiveLocationEnumValue,
+	DirectiveLocationInputObject,
+	DirectiveLocationInputFieldDefinition,
+}
+
 type Directive struct {
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	Args        []*Argument `json:"args"`
-	OnOperation bool        `json:"onOperation"`
-	OnFragment  bool        `json:"onFragment"`
-	OnField     bool        `json:"onField"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Locations   []DirectiveLocation `json:"locations"`
+	Args        []*Argument         `json:"args"`
+
+	// OnOperation, OnFragment and OnField are the legacy locations of the
+	// directive, derived from its Locations by NewDirective. The Locations of
+	// a directive configured with them only are derived from them instead.
+	OnOperation bool `json:"onOperation"`
+	OnFragment  bool `json:"onFragment"`
+	OnField     bool `json:"onField"`
+
+	// Resolve gives the directive a runtime behavior on the fields it is
+	// applied to, see DirectiveResolveFn.
+	Resolve DirectiveResolveFn `json:"-"`
+}
+
+type DirectiveResolveParams struct {
+	// Args are the arguments of the directive, coerced like field arguments.
+	Args map[string]interface{}
+	// Field are the params of the resolution of the field.
+	Field GQLFRParams
+	// Next resolves the field, it may return a deferred value.
+	Next FieldResolveFn
 }
 
+/**
+ * DirectiveResolveFn wraps the resolution of the fields the directive is
+ * applied to in a query, and returns the value of the field, usually derived
+ * from the value returned by Next. It reports errors like a FieldResolveFn.
+ *
+ * The directives of a field are applied in the order they appear in, each
+ * one wrapping the resolution by the previous ones, so that
+ * `date @formatDate(format: "2006") @upper` formats the date before
+ * uppercasing it. Directives are applied within the middlewares of the
+ * execution.
+ */
+type DirectiveResolveFn func(p DirectiveResolveParams) interface{}
+
 /**
  * Directives are used by the GraphQL runtime as a way of modifying execution
  * behavior. Type system creators will usually not create these directly.
@@ -17,14 +99,55 @@ func NewDirective(config *Directive) *Directive {
 	if config == nil {
 		config = &Directive{}
 	}
-	return &Directive{
+	directive := &Directive{
 		Name:        config.Name,
 		Description: config.Description,
+		Locations:   config.GetLocations(),
 		Args:        config.Args,
-		OnOperation: config.OnOperation,
-		OnFragment:  config.OnFragment,
-		OnField:     config.OnField,
+		Resolve:     config.Resolve,
+	}
+	for _, location := range directive.Locations {
+		switch location {
+		case DirectiveLocationQuery, DirectiveLocationMutation, DirectiveLocationSubscription:
+			directive.OnOperation = true
+		case DirectiveLocationFragmentDefinition, DirectiveLocationFragmentSpread, DirectiveLocationInlineFragment:
+			directive.OnFragment = true
+		case DirectiveLocationField:
+			directive.OnField = true
+		}
+	}
+	return directive
+}
+
+// GetLocations returns the locations of the directive, which are derived
+// from its legacy locations when it has no Locations.
+func (d *Directive) GetLocations() []DirectiveLocation {
+	if len(d.Locations) > 0 {
+		return d.Locations
+	}
+	locations := []DirectiveLocation{}
+	if d.OnOperation {
+		locations = append(locations,
+			DirectiveLocationQuery, DirectiveLocationMutation, DirectiveLocationSubscription)
 	}
+	if d.OnField {
+		locations = append(locations, DirectiveLocationField)
+	}
+	if d.OnFragment {
+		locations = append(locations, DirectiveLocationFragmentDefinition,
+			DirectiveLocationFragmentSpread, DirectiveLocationInlineFragment)
+	}
+	return locations
+}
+
+// AllowsLocation reports whether the directive may be used at the location.
+func (d *Directive) AllowsLocation(location DirectiveLocation) bool {
+	for _, allowed := range d.GetLocations() {
+		if allowed == location {
+			return true
+		}
+	}
+	return false
 }
 
 /**
@@ -41,9 +164,11 @@ var IncludeDirective *Directive = NewDirective(&Directive{
 			Description: "Included when true.",
 		},
 	},
-	OnOperation: false,
-	OnFragment:  true,
-	OnField:     true,
+	Locations: []DirectiveLocation{
+		DirectiveLocationField,
+		DirectiveLocationFragmentSpread,
+		DirectiveLocationInlineFragment,
+	},
 })
 
 /**
@@ -60,7 +185,36 @@ var SkipDirective *Directive = NewDirective(&Directive{
 			Description: "Skipped when true.",
 		},
 	},
-	OnOperation: false,
-	OnFragment:  true,
-	OnField:     true,
+	Locations: []DirectiveLocation{
+		DirectiveLocationField,
+		DirectiveLocationFragmentSpread,
+		DirectiveLocationInlineFragment,
+	},
+})
+
+// DefaultDeprecationReason is the reason of the deprecations which give none.
+const DefaultDeprecationReason = "No longer supported"
+
+/**
+ * Used to declare element of a GraphQL schema as deprecated
+ */
+var DeprecatedDirective *Directive = NewDirective(&Directive{
+	Name:        "deprecated",
+	Description: "Marks an element of a GraphQL schema as no longer supported.",
+	Args: []*Argument{
+		&Argument{
+			Name: "reason",
+			Type: String,
+			Description: "Explains why this element was deprecated, usually also including a " +
+				"suggestion for how to access supported similar data. Formatted " +
+				"in [Markdown](https://daringfireball.net/projects/markdown/).",
+			DefaultValue: DefaultDeprecationReason,
+		},
+	},
+	Locations: []DirectiveLocation{
+		DirectiveLocationFieldDefinition,
+		DirectiveLocationArgumentDefinition,
+		DirectiveLocationInputFieldDefinition,
+		DirectiveLocationEnumValue,
+	},
 })
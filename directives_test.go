@@ -2,9 +2,12 @@ package graphql_test
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/testutil"
 )
 
@@ -421,3 +424,260 @@ func TestDirectivesWorksOnFragmentUnlessTrueOmitsFragment(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+var upperDirective = graphql.NewDirective(&graphql.Directive{
+	Name:        "upper",
+	Description: "Uppercases the string value of the field.",
+	Locations:   []graphql.DirectiveLocation{graphql.DirectiveLocationField},
+	Resolve: func(p graphql.DirectiveResolveParams) interface{} {
+		value := p.Next(p.Field)
+		if value, ok := value.(string); ok {
+			return strings.ToUpper(value)
+		}
+		return value
+	},
+})
+
+var formatDateDirective = graphql.NewDirective(&graphql.Directive{
+	Name:        "formatDate",
+	Description: "Formats the time value of the field with the Go layout.",
+	Args: []*graphql.Argument{
+		&graphql.Argument{
+			Name:         "format",
+			Type:         graphql.String,
+			DefaultValue: "2006-01-02",
+		},
+	},
+	OnField: true,
+	Resolve: func(p graphql.DirectiveResolveParams) interface{} {
+		value := p.Next(p.Field)
+		if value, ok := value.(time.Time); ok {
+			return value.Format(p.Args["format"].(string))
+		}
+		return value
+	},
+})
+
+func newCustomDirectivesTestSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"name": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return "luke"
+					},
+				},
+				"birthday": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return time.Date(1977, time.May, 25, 0, 0, 0, 0, time.UTC)
+					},
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{upperDirective, formatDateDirective},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return schema
+}
+
+func TestDirectivesCustomDirectivesWrapTheResolutionOfFields(t *testing.T) {
+	query := `query Q($format: String) {
+      name @upper
+      plainName: name
+      birthday @formatDate
+      month: birthday @formatDate(format: $format) @upper
+      skipped: name @upper @skip(if: true)
+    }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"name":      "LUKE",
+			"plainName": "luke",
+			"birthday":  "1977-05-25",
+			"month":     "MAY 1977",
+		},
+	}
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:         newCustomDirectivesTestSchema(t),
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"format": "Jan 2006"},
+	}))
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesCustomDirectivesAreValidatedAndIntrospected(t *testing.T) {
+	schema := newCustomDirectivesTestSchema(t)
+	result := graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ name @lower }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Unknown directive "lower".` {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+
+	result = testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __schema { directives { name args { name type { name } } } } }`,
+	}))
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__schema": map[string]interface{}{
+				"directives": []interface{}{
+					map[string]interface{}{
+						"name": "include",
+						"args": []interface{}{
+							map[string]interface{}{"name": "if", "type": map[string]interface{}{"name": nil}},
+						},
+					},
+					map[string]interface{}{
+						"name": "skip",
+						"args": []interface{}{
+							map[string]interface{}{"name": "if", "type": map[string]interface{}{"name": nil}},
+						},
+					},
+					map[string]interface{}{
+						"name": "deprecated",
+						"args": []interface{}{
+							map[string]interface{}{"name": "reason", "type": map[string]interface{}{"name": "String"}},
+						},
+					},
+					map[string]interface{}{
+						"name": "upper",
+						"args": []interface{}{},
+					},
+					map[string]interface{}{
+						"name": "formatDate",
+						"args": []interface{}{
+							map[string]interface{}{"name": "format", "type": map[string]interface{}{"name": "String"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:      schema.GetQueryType(),
+		Directives: []*graphql.Directive{upperDirective, graphql.SkipDirective},
+	})
+	expectedErr := `Schema must contain unique named directives but contains multiple directives named "skip".`
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestDirectivesLocationsAreValidatedAndIntrospected(t *testing.T) {
+	schema := newCustomDirectivesTestSchema(t)
+	result := graphql.Graphql(graphql.Params{
+		Schema: schema,
+		RequestString: `query Q @upper {
+      ...F @upper
+      ... on Query @formatDate { name }
+    }
+    fragment F on Query { name }`,
+	})
+	expectedErrors := []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "upper" may not be used on QUERY.`, 1, 9),
+		testutil.RuleError(`Directive "upper" may not be used on FRAGMENT_SPREAD.`, 2, 12),
+		testutil.RuleError(`Directive "formatDate" may not be used on INLINE_FRAGMENT.`, 3, 20),
+	}
+	if !reflect.DeepEqual(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+
+	result = testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __schema { directives { name locations onOperation onFragment onField } } }`,
+	}))
+	fragmentOrField := map[string]interface{}{
+		"locations":   []interface{}{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		"onOperation": false,
+		"onFragment":  true,
+		"onField":     true,
+	}
+	fieldOnly := map[string]interface{}{
+		"locations":   []interface{}{"FIELD"},
+		"onOperation": false,
+		"onFragment":  false,
+		"onField":     true,
+	}
+	withName := func(name string, directive map[string]interface{}) map[string]interface{} {
+		named := map[string]interface{}{"name": name}
+		for key, value := range directive {
+			named[key] = value
+		}
+		return named
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__schema": map[string]interface{}{
+				"directives": []interface{}{
+					withName("include", fragmentOrField),
+					withName("skip", fragmentOrField),
+					map[string]interface{}{
+						"name":        "deprecated",
+						"locations":   []interface{}{"FIELD_DEFINITION", "ARGUMENT_DEFINITION", "INPUT_FIELD_DEFINITION", "ENUM_VALUE"},
+						"onOperation": false,
+						"onFragment":  false,
+						"onField":     false,
+					},
+					withName("upper", fieldOnly),
+					withName("formatDate", fieldOnly),
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesLegacyLocationsAreIntrospectedFromLocations(t *testing.T) {
+	// directives declared without NewDirective have no legacy locations set
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"name": &graphql.FieldConfig{
+					Type: graphql.String,
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{
+			&graphql.Directive{
+				Name:      "trace",
+				Locations: []graphql.DirectiveLocation{graphql.DirectiveLocationQuery, graphql.DirectiveLocationInlineFragment},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __schema { directives { name locations onOperation onFragment onField } } }`,
+	}))
+	expected := map[string]interface{}{
+		"name":        "trace",
+		"locations":   []interface{}{"QUERY", "INLINE_FRAGMENT"},
+		"onOperation": true,
+		"onFragment":  true,
+		"onField":     false,
+	}
+	if result.HasErrors() {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	directives := result.Data.(map[string]interface{})["__schema"].(map[string]interface{})["directives"].([]interface{})
+	if trace := directives[len(directives)-1]; !reflect.DeepEqual(expected, trace) {
+		t.Fatalf("Unexpected directive, Diff: %v", testutil.Diff(expected, trace))
+	}
+}
@@ -0,0 +1,154 @@
+package graphql
+
+import (
+	"container/list"
+	"reflect"
+	"sync"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+/**
+ * DocumentCache keeps the parsed and validated documents of the most
+ * recently requested query strings, so that Graphql skips the parsing and
+ * validation of repeated requests, see Params.DocumentCache.
+ *
+ * Documents are cached per query string, schema and set of validation
+ * rules, along with their syntax and validation errors. Rules cannot be told
+ * apart by their functions, the closures of a same function literal may
+ * validate differently, so the documents validated with custom rules are
+ * only cached when the params name their rules, see
+ * Params.ValidationRulesKey. Once the cache holds its capacity of documents,
+ * the least recently used document is evicted.
+ *
+ * A DocumentCache is safe for concurrent use.
+ */
+type DocumentCache struct {
+	capacity int
+
+	mu        sync.Mutex
+	entries   map[documentCacheKey]*list.Element
+	recency   *list.List
+	hits      int
+	misses    int
+	evictions int
+}
+
+// DocumentCacheStats are the counters of a DocumentCache.
+type DocumentCacheStats struct {
+	Size      int
+	Hits      int
+	Misses    int
+	Evictions int
+}
+
+type documentCacheKey struct {
+	// schema identifies the schema by its type map, which is created by
+	// NewSchema and shared by the copies of the schema.
+	schema uintptr
+	// rules is the ValidationRulesKey of the rules the document was
+	// validated with, empty for SpecifiedRules.
+	rules string
+	query string
+}
+
+type documentCacheEntry struct {
+	key      documentCacheKey
+	document *ast.Document
+	errors   []gqlerrors.FormattedError
+	// typeMap keeps the type map of the schema alive, so that its address is
+	// not reused by another schema while the entry is cached.
+	typeMap TypeMap
+}
+
+// NewDocumentCache returns a cache of at most capacity documents.
+func NewDocumentCache(capacity int) *DocumentCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &DocumentCache{
+		capacity: capacity,
+		entries:  map[documentCacheKey]*list.Element{},
+		recency:  list.New(),
+	}
+}
+
+// Stats returns the current counters of the cache.
+func (cache *DocumentCache) Stats() DocumentCacheStats {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return DocumentCacheStats{
+		Size:      cache.recency.Len(),
+		Hits:      cache.hits,
+		Misses:    cache.misses,
+		Evictions: cache.evictions,
+	}
+}
+
+// Purge removes every document of the cache, its counters are kept.
+func (cache *DocumentCache) Purge() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.entries = map[documentCacheKey]*list.Element{}
+	cache.recency.Init()
+}
+
+func newDocumentCacheKey(schema Schema, rulesKey string, query string) documentCacheKey {
+	return documentCacheKey{
+		schema: reflect.ValueOf(schema.typeMap).Pointer(),
+		rules:  rulesKey,
+		query:  query,
+	}
+}
+
+// validationRulesKey returns the key of the validation rules of the params in
+// the cache, and whether their documents may be cached at all.
+func validationRulesKey(p Params) (string, bool) {
+	if p.ValidationRulesKey != "" {
+		return p.ValidationRulesKey, true
+	}
+	rules := p.ValidationRules
+	if rules == nil || len(rules) == len(SpecifiedRules) && len(rules) > 0 && &rules[0] == &SpecifiedRules[0] {
+		return "", true
+	}
+	return "", false
+}
+
+func (cache *DocumentCache) get(schema Schema, rulesKey string, query string) (*documentCacheEntry, bool) {
+	key := newDocumentCacheKey(schema, rulesKey, query)
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	element, ok := cache.entries[key]
+	if !ok {
+		cache.misses++
+		return nil, false
+	}
+	cache.hits++
+	cache.recency.MoveToFront(element)
+	return element.Value.(*documentCacheEntry), true
+}
+
+func (cache *DocumentCache) add(schema Schema, rulesKey string, query string, document *ast.Document, errors []gqlerrors.FormattedError) {
+	entry := &documentCacheEntry{
+		key:      newDocumentCacheKey(schema, rulesKey, query),
+		document: document,
+		errors:   errors,
+		typeMap:  schema.typeMap,
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if element, ok := cache.entries[entry.key]; ok {
+		// added by a concurrent request meanwhile
+		element.Value = entry
+		cache.recency.MoveToFront(element)
+		return
+	}
+	cache.entries[entry.key] = cache.recency.PushFront(entry)
+	for cache.recency.Len() > cache.capacity {
+		oldest := cache.recency.Back()
+		cache.recency.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*documentCacheEntry).key)
+		cache.evictions++
+	}
+}
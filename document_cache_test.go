@@ -0,0 +1,211 @@
+package graphql_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestDocumentCache_ReusesTheDocumentsOfRepeatedQueries(t *testing.T) {
+	validated := 0
+	countingRule := func(context *graphql.ValidationContext) *visitor.VisitorOptions {
+		validated++
+		return &visitor.VisitorOptions{}
+	}
+	cache := graphql.NewDocumentCache(2)
+	execute := func(query string) *graphql.Result {
+		return testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+			Schema:             testutil.StarWarsSchema,
+			RequestString:      query,
+			DocumentCache:      cache,
+			ValidationRules:    append([]graphql.ValidationRule{countingRule}, graphql.SpecifiedRules...),
+			ValidationRulesKey: "counting",
+		}))
+	}
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{"name": "R2-D2"},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		if result := execute(`{ hero { name } }`); !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+	if validated != 1 {
+		t.Fatalf("Unexpected number of validations: %v", validated)
+	}
+
+	// errors are cached along with their documents
+	invalid := execute(`{ hero { unknown } }`)
+	if cached := execute(`{ hero { unknown } }`); len(invalid.Errors) != 1 || !reflect.DeepEqual(invalid, cached) {
+		t.Fatalf("Unexpected cached result, Diff: %v", testutil.Diff(invalid, cached))
+	}
+	invalid = execute(`{ hero {`)
+	if cached := execute(`{ hero {`); len(invalid.Errors) != 1 || !reflect.DeepEqual(invalid, cached) {
+		t.Fatalf("Unexpected cached result, Diff: %v", testutil.Diff(invalid, cached))
+	}
+
+	expectedStats := graphql.DocumentCacheStats{Size: 2, Hits: 4, Misses: 3, Evictions: 1}
+	if stats := cache.Stats(); !reflect.DeepEqual(expectedStats, stats) {
+		t.Fatalf("Unexpected stats, Diff: %v", testutil.Diff(expectedStats, stats))
+	}
+
+	// the least recently used document was evicted
+	execute(`{ hero { name } }`)
+	if validated != 3 {
+		t.Fatalf("Unexpected number of validations: %v", validated)
+	}
+
+	cache.Purge()
+	expectedStats = graphql.DocumentCacheStats{Size: 0, Hits: 4, Misses: 4, Evictions: 2}
+	if stats := cache.Stats(); !reflect.DeepEqual(expectedStats, stats) {
+		t.Fatalf("Unexpected stats, Diff: %v", testutil.Diff(expectedStats, stats))
+	}
+}
+
+func TestDocumentCache_CachesDocumentsPerSchema(t *testing.T) {
+	newSchema := func(fieldName string) graphql.Schema {
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.FieldConfigMap{
+					fieldName: &graphql.FieldConfig{
+						Type: graphql.String,
+						Resolve: func(p graphql.GQLFRParams) interface{} {
+							return fieldName
+						},
+					},
+				},
+			}),
+		})
+		if err != nil {
+			t.Fatalf("Error in schema %v", err.Error())
+		}
+		return schema
+	}
+	cache := graphql.NewDocumentCache(10)
+	query := `{ a }`
+
+	result := graphql.Graphql(graphql.Params{
+		Schema:        newSchema("a"),
+		RequestString: query,
+		DocumentCache: cache,
+	})
+	if result.HasErrors() {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	result = graphql.Graphql(graphql.Params{
+		Schema:        newSchema("b"),
+		RequestString: query,
+		DocumentCache: cache,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Cannot query field "a" on "Query".` {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expectedStats := graphql.DocumentCacheStats{Size: 2, Hits: 0, Misses: 2}
+	if stats := cache.Stats(); !reflect.DeepEqual(expectedStats, stats) {
+		t.Fatalf("Unexpected stats, Diff: %v", testutil.Diff(expectedStats, stats))
+	}
+}
+
+func TestDocumentCache_CachesDocumentsPerValidationRules(t *testing.T) {
+	// the rules forbidding each field are closures of a same function literal
+	forbidFieldRule := func(name string) graphql.ValidationRule {
+		return func(context *graphql.ValidationContext) *visitor.VisitorOptions {
+			return &visitor.VisitorOptions{
+				KindFuncMap: map[string]visitor.NamedVisitFuncs{
+					kinds.Field: visitor.NamedVisitFuncs{
+						Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+							if node, ok := p.Node.(*ast.Field); ok && node.Name.Value == name {
+								context.ReportError(graphql.NewLocatedError(
+									`Field "`+name+`" is not allowed.`, []ast.Node{node}))
+							}
+							return visitor.ActionNoChange, nil
+						},
+					},
+				},
+			}
+		}
+	}
+	forbidding := func(name string) []graphql.ValidationRule {
+		return append([]graphql.ValidationRule{forbidFieldRule(name)}, graphql.SpecifiedRules...)
+	}
+	cache := graphql.NewDocumentCache(10)
+	execute := func(rules []graphql.ValidationRule, rulesKey string) []gqlerrors.FormattedError {
+		return graphql.Graphql(graphql.Params{
+			Schema:             testutil.StarWarsSchema,
+			RequestString:      `{ hero { name } }`,
+			DocumentCache:      cache,
+			ValidationRules:    rules,
+			ValidationRulesKey: rulesKey,
+		}).Errors
+	}
+	nameForbidden := `Field "name" is not allowed.`
+
+	tests := []struct {
+		rules    []graphql.ValidationRule
+		rulesKey string
+		message  string
+	}{
+		{nil, "", ""},
+		{graphql.SpecifiedRules, "", ""},
+		// custom rules without key are never cached
+		{forbidding("name"), "", nameForbidden},
+		{forbidding("id"), "", ""},
+		{forbidding("name"), "", nameForbidden},
+		{forbidding("name"), "no-name", nameForbidden},
+		{forbidding("id"), "no-id", ""},
+		{forbidding("name"), "no-name", nameForbidden},
+	}
+	for i, test := range tests {
+		errs := execute(test.rules, test.rulesKey)
+		message := ""
+		if len(errs) > 0 {
+			message = errs[0].Message
+		}
+		if len(errs) > 1 || message != test.message {
+			t.Fatalf("Unexpected errors of request %v: %v", i, errs)
+		}
+	}
+	expectedStats := graphql.DocumentCacheStats{Size: 3, Hits: 2, Misses: 3}
+	if stats := cache.Stats(); !reflect.DeepEqual(expectedStats, stats) {
+		t.Fatalf("Unexpected stats, Diff: %v", testutil.Diff(expectedStats, stats))
+	}
+}
+
+func TestDocumentCache_IsSafeForConcurrentUse(t *testing.T) {
+	cache := graphql.NewDocumentCache(2)
+	queries := []string{
+		`{ hero { name } }`,
+		`{ hero { id } }`,
+		`{ hero { name friends { name } } }`,
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(query string) {
+			defer wg.Done()
+			result := graphql.Graphql(graphql.Params{
+				Schema:        testutil.StarWarsSchema,
+				RequestString: query,
+				DocumentCache: cache,
+			})
+			if result.HasErrors() {
+				t.Errorf("Unexpected errors: %v", result.Errors)
+			}
+		}(queries[i%len(queries)])
+	}
+	wg.Wait()
+	if stats := cache.Stats(); stats.Hits+stats.Misses != 30 || stats.Size != 2 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+}
@@ -1,10 +1,12 @@
 package graphql
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
@@ -16,11 +18,61 @@ type ExecuteParams struct {
 	AST           *ast.Document
 	OperationName string
 	Args          map[string]interface{}
+
+	// Context is passed to every field resolver. Once it is cancelled or its
+	// deadline expires, remaining fields are not resolved and resolve to null
+	// with an error instead. Defaults to context.Background().
+	Context context.Context
+
+	// Concurrency is the maximum number of fields and list items resolved at
+	// the same time in separate goroutines, resolvers must then be safe for
+	// concurrent use. Mutation root fields are always resolved serially.
+	// Fields are resolved one at a time when Concurrency is 0 or 1.
+	Concurrency int
+
+	// MaxDepth and MaxComplexity reject operations deeper or more complex
+	// than the limits with an error, before any field is resolved, see
+	// AnalyzeComplexity. Operations are not limited when they are 0.
+	MaxDepth      int
+	MaxComplexity int
+
+	// Middlewares wrap the resolve function of every field, including the
+	// default one, the first middleware being the outermost one.
+	Middlewares []FieldMiddleware
+
+	// Instrumentation observes the execution and the resolution of every
+	// field, its field hook wraps the middlewares.
+	Instrumentation Instrumentation
+
+	// Extensions contribute entries to the extensions of the result once the
+	// operation is executed.
+	Extensions []Extension
+
+	// complexity is the complexity of the operation measured while checking
+	// MaxDepth and MaxComplexity, which ComplexityExtension reuses.
+	complexity *OperationComplexity
 }
 
 func Execute(p ExecuteParams) (result *Result) {
 	result = &Result{}
 
+	if p.Instrumentation != nil {
+		if p.Context == nil {
+			p.Context = context.Background()
+		}
+		if finish := p.Instrumentation.InstrumentExecute(p.Context, p); finish != nil {
+			defer func() {
+				finish(result)
+			}()
+		}
+		p.Middlewares = append([]FieldMiddleware{instrumentFields(p.Instrumentation)}, p.Middlewares...)
+	}
+	if len(p.Extensions) > 0 {
+		defer func() {
+			contributeExtensions(p.Extensions, p, result)
+		}()
+	}
+
 	exeContext, err := buildExecutionContext(BuildExecutionCtxParams{
 		Schema:        p.Schema,
 		Root:          p.Root,
@@ -29,6 +81,9 @@ func Execute(p ExecuteParams) (result *Result) {
 		Args:          p.Args,
 		Errors:        nil,
 		Result:        result,
+		Context:       p.Context,
+		Concurrency:   p.Concurrency,
+		Middlewares:   p.Middlewares,
 	})
 
 	if err != nil {
@@ -42,11 +97,20 @@ func Execute(p ExecuteParams) (result *Result) {
 			if r, ok := r.(error); ok {
 				err = gqlerrors.FormatError(r)
 			}
-			exeContext.Errors = append(exeContext.Errors, gqlerrors.FormatError(err))
-			result.Errors = exeContext.Errors
+			exeContext.addError(gqlerrors.FormatError(err))
+			result.Errors = exeContext.getErrors()
 		}
 	}()
 
+	if p.complexity == nil {
+		complexity, err := checkComplexity(exeContext, p.MaxDepth, p.MaxComplexity)
+		if err != nil {
+			result.Errors = append(result.Errors, gqlerrors.FormatError(err))
+			return
+		}
+		p.complexity = complexity
+	}
+
 	return executeOperation(ExecuteOperationParams{
 		ExecutionContext: exeContext,
 		Root:             p.Root,
@@ -62,6 +126,9 @@ type BuildExecutionCtxParams struct {
 	Args          map[string]interface{}
 	Errors        []gqlerrors.FormattedError
 	Result        *Result
+	Context       context.Context
+	Concurrency   int
+	Middlewares   []FieldMiddleware
 }
 type ExecutionContext struct {
 	Schema         Schema
@@ -70,6 +137,304 @@ type ExecutionContext struct {
 	Operation      ast.Definition
 	VariableValues map[string]interface{}
 	Errors         []gqlerrors.FormattedError
+	Context        context.Context
+
+	// workers holds a token for each goroutine resolving fields, it is nil
+	// when fields are resolved sequentially.
+	workers     chan struct{}
+	errorsMutex sync.Mutex
+
+	middlewares []FieldMiddleware
+
+	// deferred holds the completions of the values deferred by resolvers
+	// during the current phase of the execution.
+	deferred      []*deferredCompletion
+	deferredMutex sync.Mutex
+}
+
+// addError records a field error, it is safe for concurrent use.
+func (eCtx *ExecutionContext) addError(err gqlerrors.FormattedError) {
+	eCtx.errorsMutex.Lock()
+	defer eCtx.errorsMutex.Unlock()
+	eCtx.Errors = append(eCtx.Errors, err)
+}
+
+func (eCtx *ExecutionContext) getErrors() []gqlerrors.FormattedError {
+	eCtx.errorsMutex.Lock()
+	defer eCtx.errorsMutex.Unlock()
+	return eCtx.Errors
+}
+
+// runAll calls every given function and waits for them to return. When the
+// execution is concurrent, functions run in new goroutines as long as there
+// are free workers, and in the calling goroutine otherwise, so that nested
+// selections can never wait on workers held by their parents.
+// A panic raised by a function, such as a non-null field error, stops the
+// remaining functions from starting and is raised again in the calling
+// goroutine once all started functions returned.
+func (eCtx *ExecutionContext) runAll(fns []func()) {
+	if eCtx.workers == nil || len(fns) < 2 {
+		for _, fn := range fns {
+			fn()
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	var panicMutex sync.Mutex
+	var panicValue interface{}
+	recordPanic := func() {
+		if r := recover(); r != nil {
+			panicMutex.Lock()
+			if panicValue == nil {
+				panicValue = r
+			}
+			panicMutex.Unlock()
+		}
+	}
+	for _, fn := range fns {
+		select {
+		case eCtx.workers <- struct{}{}:
+			wg.Add(1)
+			go func(fn func()) {
+				defer func() {
+					<-eCtx.workers
+					wg.Done()
+				}()
+				defer recordPanic()
+				fn()
+			}(fn)
+		default:
+			func() {
+				defer recordPanic()
+				fn()
+			}()
+		}
+		panicMutex.Lock()
+		failed := panicValue != nil
+		panicMutex.Unlock()
+		if failed {
+			break
+		}
+	}
+	// functions already running in workers are always waited for, so that
+	// none of them outlives the execution even when another one panicked
+	wg.Wait()
+	if panicValue != nil {
+		panic(panicValue)
+	}
+}
+
+type deferredCompletion struct {
+	deferred Thunk
+	complete func(value interface{}, err error)
+	value    interface{}
+	err      error
+}
+
+// deferCompletion queues the completion of a thunk for the next phase of the
+// execution, and returns the pending completed value.
+func (eCtx *ExecutionContext) deferCompletion(deferred Thunk, returnType Type, fieldASTs []*ast.Field, info ResolveInfo) *pendingResult {
+	pending := newPendingResult()
+	completion := &deferredCompletion{
+		deferred: deferred,
+		complete: func(value interface{}, err error) {
+			pending.settleWith(func() interface{} {
+				if err != nil {
+					panic(withPath(gqlerrors.FormatError(NewLocatedError(err, FieldASTsToNodeASTs(fieldASTs))), info.Path))
+				}
+				return completeValueCatchingError(eCtx, returnType, fieldASTs, info, value)
+			})
+		},
+	}
+	eCtx.deferredMutex.Lock()
+	eCtx.deferred = append(eCtx.deferred, completion)
+	eCtx.deferredMutex.Unlock()
+
+	if _, ok := returnType.(*NonNull); ok {
+		return pending
+	}
+	return catchPendingError(eCtx, pending, info.Path)
+}
+
+/**
+ * Runs a phase of the execution: the thunks returned during the previous
+ * phase are awaited together, so that the keys requested from a Loader are
+ * loaded with a single batch and futures run at the same time, then their
+ * fields are completed, possibly deferring values to the next phase.
+ * Returns false when there was no deferred value to complete.
+ */
+func (eCtx *ExecutionContext) runPhase() bool {
+	eCtx.deferredMutex.Lock()
+	deferred := eCtx.deferred
+	eCtx.deferred = nil
+	eCtx.deferredMutex.Unlock()
+	if len(deferred) == 0 {
+		return false
+	}
+
+	awaits := []func(){}
+	completions := []func(){}
+	for _, completion := range deferred {
+		completion := completion
+		awaits = append(awaits, func() {
+			completion.value, completion.err = awaitDeferredValue(completion.deferred)
+		})
+		completions = append(completions, func() {
+			completion.complete(completion.value, completion.err)
+		})
+	}
+	eCtx.runAll(awaits)
+	eCtx.runAll(completions)
+	return true
+}
+
+func awaitDeferredValue(deferred Thunk) (value interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	return deferred.Value()
+}
+
+// await runs the phases of the execution until the value is completed. The
+// error of a value failing a non-null field is raised like in a resolver.
+func (eCtx *ExecutionContext) await(value interface{}) interface{} {
+	pending, ok := value.(*pendingResult)
+	if !ok {
+		return value
+	}
+	for !pending.isSettled() && eCtx.runPhase() {
+	}
+	value, err := pending.result()
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// pendingResult is a completed value which depends on values deferred to a
+// later phase of the execution. It is rejected with the error raised while
+// completing a non-null field, which propagates to the closest nullable one.
+type pendingResult struct {
+	mutex     sync.Mutex
+	settled   bool
+	value     interface{}
+	err       interface{}
+	callbacks []func(value interface{}, err interface{})
+}
+
+func newPendingResult() *pendingResult {
+	return &pendingResult{}
+}
+
+func (p *pendingResult) settle(value interface{}, err interface{}) {
+	p.mutex.Lock()
+	if p.settled {
+		p.mutex.Unlock()
+		return
+	}
+	p.settled = true
+	p.value, p.err = value, err
+	callbacks := p.callbacks
+	p.callbacks = nil
+	p.mutex.Unlock()
+	for _, callback := range callbacks {
+		callback(value, err)
+	}
+}
+
+// settleWith settles the result with the value returned by fn once it is
+// completed, or rejects it with the error fn panics with.
+func (p *pendingResult) settleWith(fn func() interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.settle(nil, r)
+		}
+	}()
+	value := fn()
+	if value, ok := value.(*pendingResult); ok {
+		value.then(p.settle)
+		return
+	}
+	p.settle(value, nil)
+}
+
+// then calls the callback once the result is settled.
+func (p *pendingResult) then(callback func(value interface{}, err interface{})) {
+	p.mutex.Lock()
+	if !p.settled {
+		p.callbacks = append(p.callbacks, callback)
+		p.mutex.Unlock()
+		return
+	}
+	p.mutex.Unlock()
+	callback(p.value, p.err)
+}
+
+func (p *pendingResult) isSettled() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.settled
+}
+
+func (p *pendingResult) result() (interface{}, interface{}) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.value, p.err
+}
+
+// catchPendingError completes a nullable value to null when it is rejected,
+// recording the error like completeValueCatchingError.
+func catchPendingError(eCtx *ExecutionContext, pending *pendingResult, path *ResponsePath) *pendingResult {
+	caught := newPendingResult()
+	pending.then(func(value interface{}, err interface{}) {
+		if err != nil {
+			if err, ok := err.(gqlerrors.FormattedError); ok {
+				eCtx.addError(withPath(err, path))
+			}
+			caught.settle(nil, nil)
+			return
+		}
+		caught.settle(value, nil)
+	})
+	return caught
+}
+
+// joinPending calls build with the values once none of them is pending
+// anymore. The joined value is pending as long as one of the values is, and
+// is rejected as soon as one of them is.
+func joinPending(values []interface{}, build func(values []interface{}) interface{}) interface{} {
+	pendingIndices := []int{}
+	for i, value := range values {
+		if _, ok := value.(*pendingResult); ok {
+			pendingIndices = append(pendingIndices, i)
+		}
+	}
+	if len(pendingIndices) == 0 {
+		return build(values)
+	}
+	joined := newPendingResult()
+	var mutex sync.Mutex
+	remaining := len(pendingIndices)
+	for _, i := range pendingIndices {
+		i := i
+		values[i].(*pendingResult).then(func(value interface{}, err interface{}) {
+			if err != nil {
+				joined.settle(nil, err)
+				return
+			}
+			mutex.Lock()
+			values[i] = value
+			remaining--
+			done := remaining == 0
+			mutex.Unlock()
+			if done {
+				joined.settle(build(values), nil)
+			}
+		})
+	}
+	return joined
 }
 
 func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error) {
@@ -124,6 +489,15 @@ func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error)
 	eCtx.Operation = operation
 	eCtx.VariableValues = variableValues
 	eCtx.Errors = p.Errors
+	eCtx.Context = p.Context
+	if eCtx.Context == nil {
+		eCtx.Context = context.Background()
+	}
+	if p.Concurrency > 1 {
+		// the calling goroutine resolves fields as well
+		eCtx.workers = make(chan struct{}, p.Concurrency-1)
+	}
+	eCtx.middlewares = p.Middlewares
 	return eCtx, nil
 }
 
@@ -152,17 +526,22 @@ func executeOperation(p ExecuteOperationParams) *Result {
 		Fields:           fields,
 	}
 
+	var result *Result
 	if p.Operation.GetOperation() == "mutation" {
-		return executeFieldsSerially(executeFieldsParams)
+		result = executeFieldsSerially(executeFieldsParams)
 	} else {
-		return executeFields(executeFieldsParams)
+		result = executeFields(executeFieldsParams)
 	}
+	// complete the values deferred by resolvers, level by level
+	result.Data = p.ExecutionContext.await(result.Data)
+	result.Errors = p.ExecutionContext.getErrors()
+	return result
 }
 
 // Extracts the root type of the operation from the schema.
 func getOperationRootType(schema Schema, operation ast.Definition) (*Object, error) {
 	if operation == nil {
-		return nil, errors.New("Can only execute queries and mutations")
+		return nil, errors.New("Can only execute queries, mutations and subscriptions")
 	}
 
 	switch operation.GetOperation() {
@@ -170,12 +549,18 @@ func getOperationRootType(schema Schema, operation ast.Definition) (*Object, err
 		return schema.GetQueryType(), nil
 	case "mutation":
 		mutationType := schema.GetMutationType()
-		if mutationType.Name == "" {
+		if mutationType == nil || mutationType.Name == "" {
 			return nil, errors.New("Schema is not configured for mutations")
 		}
 		return mutationType, nil
+	case "subscription":
+		subscriptionType := schema.GetSubscriptionType()
+		if subscriptionType == nil || subscriptionType.Name == "" {
+			return nil, errors.New("Schema is not configured for subscriptions")
+		}
+		return subscriptionType, nil
 	default:
-		return nil, errors.New("Can only execute queries and mutations")
+		return nil, errors.New("Can only execute queries, mutations and subscriptions")
 	}
 }
 
@@ -183,7 +568,8 @@ type ExecuteFieldsParams struct {
 	ExecutionContext *ExecutionContext
 	ParentType       *Object
 	Source           interface{}
-	Fields           map[string][]*ast.Field
+	Fields           *FieldASTsMap
+	Path             *ResponsePath
 }
 
 // Implements the "Evaluating selection sets" section of the spec for "write" mode.
@@ -192,21 +578,22 @@ func executeFieldsSerially(p ExecuteFieldsParams) *Result {
 		p.Source = map[string]interface{}{}
 	}
 	if p.Fields == nil {
-		p.Fields = map[string][]*ast.Field{}
+		p.Fields = NewFieldASTsMap()
 	}
 
-	finalResults := map[string]interface{}{}
-	for responseName, fieldASTs := range p.Fields {
-		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs)
+	finalResults := NewOrderedMap()
+	for _, responseName := range p.Fields.Names {
+		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, p.Fields.Fields[responseName], p.Path.WithKey(responseName))
 		if state.hasNoFieldDefs {
 			continue
 		}
-		finalResults[responseName] = resolved
+		// the field is completed before the next one is resolved
+		finalResults.Set(responseName, p.ExecutionContext.await(resolved))
 	}
 
 	return &Result{
 		Data:   finalResults,
-		Errors: p.ExecutionContext.Errors,
+		Errors: p.ExecutionContext.getErrors(),
 	}
 }
 
@@ -216,21 +603,36 @@ func executeFields(p ExecuteFieldsParams) *Result {
 		p.Source = map[string]interface{}{}
 	}
 	if p.Fields == nil {
-		p.Fields = map[string][]*ast.Field{}
+		p.Fields = NewFieldASTsMap()
 	}
 
-	finalResults := map[string]interface{}{}
-	for responseName, fieldASTs := range p.Fields {
-		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs)
-		if state.hasNoFieldDefs {
-			continue
-		}
-		finalResults[responseName] = resolved
+	responseNames := p.Fields.Names
+	resolvedFields := make([]interface{}, len(responseNames))
+	resultStates := make([]resolveFieldResultState, len(responseNames))
+	fns := []func(){}
+	for i, responseName := range responseNames {
+		i, fieldASTs, path := i, p.Fields.Fields[responseName], p.Path.WithKey(responseName)
+		fns = append(fns, func() {
+			resolvedFields[i], resultStates[i] = resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs, path)
+		})
 	}
+	p.ExecutionContext.runAll(fns)
+
+	// the fields completed in a later phase are set once they all are
+	finalResults := joinPending(resolvedFields, func(resolvedFields []interface{}) interface{} {
+		finalResults := NewOrderedMap()
+		for i, responseName := range responseNames {
+			if resultStates[i].hasNoFieldDefs {
+				continue
+			}
+			finalResults.Set(responseName, resolvedFields[i])
+		}
+		return finalResults
+	})
 
 	return &Result{
 		Data:   finalResults,
-		Errors: p.ExecutionContext.Errors,
+		Errors: p.ExecutionContext.getErrors(),
 	}
 }
 
@@ -238,17 +640,39 @@ type CollectFieldsParams struct {
 	ExeContext           *ExecutionContext
 	OperationType        *Object
 	SelectionSet         *ast.SelectionSet
-	Fields               map[string][]*ast.Field
+	Fields               *FieldASTsMap
 	VisitedFragmentNames map[string]bool
 }
 
+// FieldASTsMap maps the response names of a selection set to the field ASTs
+// selected under them, Names holds the response names in the order they
+// first appear in the query.
+type FieldASTsMap struct {
+	Names  []string
+	Fields map[string][]*ast.Field
+}
+
+func NewFieldASTsMap() *FieldASTsMap {
+	return &FieldASTsMap{
+		Names:  []string{},
+		Fields: map[string][]*ast.Field{},
+	}
+}
+
+func (fm *FieldASTsMap) add(responseName string, field *ast.Field) {
+	if _, ok := fm.Fields[responseName]; !ok {
+		fm.Names = append(fm.Names, responseName)
+	}
+	fm.Fields[responseName] = append(fm.Fields[responseName], field)
+}
+
 // Given a selectionSet, adds all of the fields in that selection to
 // the passed in map of fields, and returns it at the end.
-func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
+func collectFields(p CollectFieldsParams) *FieldASTsMap {
 
 	fields := p.Fields
 	if fields == nil {
-		fields = map[string][]*ast.Field{}
+		fields = NewFieldASTsMap()
 	}
 	if p.VisitedFragmentNames == nil {
 		p.VisitedFragmentNames = map[string]bool{}
@@ -262,11 +686,7 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 			if !shouldIncludeNode(p.ExeContext, selection.Directives) {
 				continue
 			}
-			name := getFieldEntryKey(selection)
-			if _, ok := fields[name]; !ok {
-				fields[name] = []*ast.Field{}
-			}
-			fields[name] = append(fields[name], selection)
+			fields.add(getFieldEntryKey(selection), selection)
 		case *ast.InlineFragment:
 
 			if !shouldIncludeNode(p.ExeContext, selection.Directives) ||
@@ -432,7 +852,7 @@ type resolveFieldResultState struct {
  * then calls completeValue to complete promises, serialize scalars, or execute
  * the sub-selection-set for objects.
  */
-func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}, fieldASTs []*ast.Field) (result interface{}, resultState resolveFieldResultState) {
+func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}, fieldASTs []*ast.Field, path *ResponsePath) (result interface{}, resultState resolveFieldResultState) {
 	// catch panic from resolveFn
 	var returnType Output
 	defer func() (interface{}, resolveFieldResultState) {
@@ -450,9 +870,9 @@ func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}
 			}
 			// send panic upstream
 			if _, ok := returnType.(*NonNull); ok {
-				panic(gqlerrors.FormatError(err))
+				panic(withPath(gqlerrors.FormatError(err), path))
 			}
-			eCtx.Errors = append(eCtx.Errors, gqlerrors.FormatError(err))
+			eCtx.addError(withPath(gqlerrors.FormatError(err), path))
 			return result, resultState
 		}
 		return result, resultState
@@ -470,9 +890,11 @@ func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}
 		return nil, resultState
 	}
 	returnType = fieldDef.Type
-	resolveFn := fieldDef.Resolve
-	if resolveFn == nil {
-		resolveFn = defaultResolveFn
+
+	// Stop resolving fields once the request has been cancelled, the error is
+	// handled like any error raised by a resolve function.
+	if err := eCtx.Context.Err(); err != nil {
+		panic(NewLocatedError(err, FieldASTsToNodeASTs(fieldASTs)))
 	}
 
 	// Build a map of arguments from the field.arguments AST, using the
@@ -492,32 +914,44 @@ func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}
 		RootValue:      eCtx.Root,
 		Operation:      eCtx.Operation,
 		VariableValues: eCtx.VariableValues,
+		Path:           path,
 	}
 
 	// TODO: If an error occurs while calling the field `resolve` function, ensure that
 	// it is wrapped as a Error with locations. Log this error and return
 	// null if allowed, otherwise throw the error so the parent field can handle
 	// it.
-	result = resolveFn(GQLFRParams{
-		Source: source,
-		Args:   args,
-		Info:   info,
-	})
+	resolveParams := GQLFRParams{
+		Source:  source,
+		Args:    args,
+		Info:    info,
+		Context: eCtx.Context,
+	}
+	result = resolveFnOf(eCtx, fieldDef, fieldAST)(resolveParams)
 
 	completed := completeValueCatchingError(eCtx, returnType, fieldASTs, info, result)
 	return completed, resultState
 }
 
 func completeValueCatchingError(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, result interface{}) (completed interface{}) {
+	// Thunks returned by the resolver are completed in a later phase, along
+	// with every other thunk returned meanwhile.
+	if thunk, ok := asThunk(result); ok {
+		return eCtx.deferCompletion(thunk, returnType, fieldASTs, info)
+	}
+
 	// catch panic
 	defer func() interface{} {
 		if r := recover(); r != nil {
+			if err, ok := r.(gqlerrors.FormattedError); ok {
+				r = withPath(err, info.Path)
+			}
 			//send panic upstream
 			if _, ok := returnType.(*NonNull); ok {
 				panic(r)
 			}
 			if err, ok := r.(gqlerrors.FormattedError); ok {
-				eCtx.Errors = append(eCtx.Errors, err)
+				eCtx.addError(err)
 			}
 			return completed
 		}
@@ -529,27 +963,19 @@ func completeValueCatchingError(eCtx *ExecutionContext, returnType Type, fieldAS
 		return completed
 	}
 	completed = completeValue(eCtx, returnType, fieldASTs, info, result)
-	resultVal := reflect.ValueOf(completed)
-	if resultVal.IsValid() && resultVal.Type().Kind() == reflect.Func {
-		if propertyFn, ok := completed.(func() interface{}); ok {
-			return propertyFn()
-		}
-		err := gqlerrors.NewFormattedError("Error resolving func. Expected `func() interface{}` signature")
-		panic(gqlerrors.FormatError(err))
+	if pending, ok := completed.(*pendingResult); ok {
+		return catchPendingError(eCtx, pending, info.Path)
 	}
 	return completed
 }
 
 func completeValue(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, result interface{}) interface{} {
 
-	// TODO: explore resolving go-routines in completeValue
-
+	// functions are only awaited as thunks
 	resultVal := reflect.ValueOf(result)
 	if resultVal.IsValid() && resultVal.Type().Kind() == reflect.Func {
-		if propertyFn, ok := result.(func() interface{}); ok {
-			return propertyFn()
-		}
-		err := gqlerrors.NewFormattedError("Error resolving func. Expected `func() interface{}` signature")
+		err := gqlerrors.NewFormattedError("Error resolving func. Expected `func() interface{}` " +
+			"or `func() (interface{}, error)` signature")
 		panic(gqlerrors.FormatError(err))
 	}
 
@@ -582,13 +1008,19 @@ func completeValue(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Fie
 		}
 
 		itemType := returnType.OfType
-		completedResults := []interface{}{}
+		completedResults := make([]interface{}, resultVal.Len())
+		fns := []func(){}
 		for i := 0; i < resultVal.Len(); i++ {
-			val := resultVal.Index(i).Interface()
-			completedItem := completeValueCatchingError(eCtx, itemType, fieldASTs, info, val)
-			completedResults = append(completedResults, completedItem)
+			i, val, itemInfo := i, resultVal.Index(i).Interface(), info
+			itemInfo.Path = info.Path.WithKey(i)
+			fns = append(fns, func() {
+				completedResults[i] = completeValueCatchingError(eCtx, itemType, fieldASTs, itemInfo, val)
+			})
 		}
-		return completedResults
+		eCtx.runAll(fns)
+		return joinPending(completedResults, func(completedResults []interface{}) interface{} {
+			return completedResults
+		})
 	}
 
 	// If field type is Scalar or Enum, serialize to a valid value, returning
@@ -644,7 +1076,7 @@ func completeValue(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Fie
 	}
 
 	// Collect sub-fields to execute to complete this value.
-	subFieldASTs := map[string][]*ast.Field{}
+	subFieldASTs := NewFieldASTsMap()
 	visitedFragmentNames := map[string]bool{}
 	for _, fieldAST := range fieldASTs {
 		if fieldAST == nil {
@@ -667,6 +1099,7 @@ func completeValue(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Fie
 		ParentType:       objectType,
 		Source:           result,
 		Fields:           subFieldASTs,
+		Path:             info.Path,
 	}
 	results := executeFields(executeFieldsParams)
 
@@ -674,6 +1107,15 @@ func completeValue(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Fie
 
 }
 
+// withPath locates the error at the given field path, unless it was already
+// located at the deeper field it was raised at.
+func withPath(err gqlerrors.FormattedError, path *ResponsePath) gqlerrors.FormattedError {
+	if err.Path == nil {
+		err.Path = path.AsArray()
+	}
+	return err
+}
+
 func defaultResolveFn(p GQLFRParams) interface{} {
 	// try to resolve p.Source as a struct first
 	sourceVal := reflect.ValueOf(p.Source)
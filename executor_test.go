@@ -1,10 +1,15 @@
 package graphql_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/gqlerrors"
@@ -344,6 +349,182 @@ func TestThreadsContextCorrectly(t *testing.T) {
 	}
 }
 
+func TestThreadsRequestContextToResolvers(t *testing.T) {
+
+	query := `
+      query Example { a }
+    `
+
+	type contextKey string
+	ctx := context.WithValue(context.Background(), contextKey("user"), "luke")
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.FieldConfigMap{
+				"a": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return p.Context.Value(contextKey("user"))
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		Context:       ctx,
+	}))
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "luke",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDefaultsRequestContextToBackground(t *testing.T) {
+
+	var resolvedContext context.Context
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.FieldConfigMap{
+				"a": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						resolvedContext = p.Context
+						return "a"
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `{ a }`),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if resolvedContext != context.Background() {
+		t.Fatalf("Expected resolver context to be context.Background(), got %v", resolvedContext)
+	}
+}
+
+func TestStopsResolvingFieldsOnceContextIsCancelled(t *testing.T) {
+
+	query := `{ a, b { c }, d }`
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resolved := []string{}
+	var bType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "B",
+		Fields: graphql.FieldConfigMap{
+			"c": &graphql.FieldConfig{
+				Type: graphql.String,
+				Resolve: func(p graphql.GQLFRParams) interface{} {
+					resolved = append(resolved, "c")
+					return "c"
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.FieldConfigMap{
+				"a": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						resolved = append(resolved, "a")
+						return "a"
+					},
+				},
+				"b": &graphql.FieldConfig{
+					Type: bType,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						resolved = append(resolved, "b")
+						// the request is cancelled while resolving b
+						cancel()
+						return map[string]interface{}{}
+					},
+				},
+				"d": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						resolved = append(resolved, "d")
+						return "d"
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema:  schema,
+		AST:     testutil.TestParse(t, query),
+		Context: ctx,
+	})
+
+	// fields are resolved in an unspecified order, b's sub-field is never resolved
+	for _, name := range resolved {
+		if name == "c" {
+			t.Fatalf("Expected c not to be resolved once the context is cancelled, resolved: %v", resolved)
+		}
+	}
+	cancelledFields := map[string]bool{}
+	for _, name := range []string{"a", "d"} {
+		if !contains(resolved, name) {
+			cancelledFields[name] = true
+		}
+	}
+	expectedErrorCount := len(cancelledFields) + 1
+	if len(result.Errors) != expectedErrorCount {
+		t.Fatalf("Expected %v errors, got: %v", expectedErrorCount, result.Errors)
+	}
+	for _, err := range result.Errors {
+		if err.Message != context.Canceled.Error() {
+			t.Fatalf("Unexpected error message: %v", err.Message)
+		}
+		if len(err.Locations) != 1 {
+			t.Fatalf("Expected error to be located, got: %v", err.Locations)
+		}
+	}
+	data := result.Data.(map[string]interface{})
+	if b := data["b"].(map[string]interface{}); b["c"] != nil {
+		t.Fatalf("Expected b.c to be null, got: %v", b["c"])
+	}
+	for name := range cancelledFields {
+		if data[name] != nil {
+			t.Fatalf("Expected %v to be null, got: %v", name, data[name])
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func TestCorrectlyThreadsArguments(t *testing.T) {
 
 	query := `
@@ -423,6 +604,7 @@ func TestNullsOutErrorSubtrees(t *testing.T) {
 					Line: 3, Column: 7,
 				},
 			},
+			Path: []interface{}{"syncError"},
 		},
 	}
 
@@ -472,6 +654,465 @@ func TestNullsOutErrorSubtrees(t *testing.T) {
 	}
 }
 
+func TestNullsOutFieldsWhoseResolverReturnsAnError(t *testing.T) {
+
+	query := `{
+      sync,
+      syncError,
+    }`
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"sync":      "sync",
+			"syncError": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message: "Error getting syncError",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{
+						Line: 3, Column: 7,
+					},
+				},
+				Path: []interface{}{"syncError"},
+			},
+		},
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.FieldConfigMap{
+				"sync": &graphql.FieldConfig{
+					Type: graphql.String,
+					ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+						return "sync", nil
+					},
+				},
+				"syncError": &graphql.FieldConfig{
+					Type: graphql.String,
+					ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+						return "ignored", errors.New("Error getting syncError")
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, query),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestResolverErrorsOnNonNullFieldsNullTheParent(t *testing.T) {
+
+	query := `{
+      nest {
+        nonNullSyncError
+      }
+    }`
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"nest": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message: "Error getting nonNullSyncError",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{
+						Line: 3, Column: 9,
+					},
+				},
+				Path: []interface{}{"nest", "nonNullSyncError"},
+			},
+		},
+	}
+
+	nestType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Nest",
+		Fields: graphql.FieldConfigMap{
+			"nonNullSyncError": &graphql.FieldConfig{
+				Type: graphql.NewNonNull(graphql.String),
+				ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+					return nil, errors.New("Error getting nonNullSyncError")
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.FieldConfigMap{
+				"nest": &graphql.FieldConfig{
+					Type: nestType,
+					ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+						return map[string]interface{}{}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, query),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+// codedError is a resolver error reporting an error code in its extensions.
+type codedError struct {
+	message string
+	code    string
+}
+
+func (e codedError) Error() string {
+	return e.message
+}
+
+func (e codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func TestReportsPathAndExtensionsOfResolverErrors(t *testing.T) {
+
+	query := `{
+      items {
+        name
+        secret
+      }
+    }`
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{
+					"name":   "first",
+					"secret": nil,
+				},
+				map[string]interface{}{
+					"name":   "second",
+					"secret": "revealed",
+				},
+				nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message: "Not allowed to read first",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{
+						Line: 4, Column: 9,
+					},
+				},
+				Path:       []interface{}{"items", 0, "secret"},
+				Extensions: map[string]interface{}{"code": "FORBIDDEN"},
+			},
+			gqlerrors.FormattedError{
+				Message:    "Cannot read third",
+				Locations:  []location.SourceLocation{},
+				Path:       []interface{}{"items", 2, "name"},
+				Extensions: map[string]interface{}{"code": "INTERNAL"},
+			},
+		},
+	}
+
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.FieldConfigMap{
+			"name": &graphql.FieldConfig{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.GQLFRParams) interface{} {
+					name := p.Source.(string)
+					if name == "third" {
+						panic(codedError{"Cannot read third", "INTERNAL"})
+					}
+					return name
+				},
+			},
+			"secret": &graphql.FieldConfig{
+				Type: graphql.String,
+				ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+					if name := p.Source.(string); name == "first" {
+						return nil, codedError{"Not allowed to read " + name, "FORBIDDEN"}
+					}
+					return "revealed", nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"items": &graphql.FieldConfig{
+					Type: graphql.NewList(itemType),
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return []string{"first", "second", "third"}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, query),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	expectedJSON := `[{"message":"Not allowed to read first","locations":[{"line":4,"column":9}],` +
+		`"path":["items",0,"secret"],"extensions":{"code":"FORBIDDEN"}},` +
+		`{"message":"Cannot read third","path":["items",2,"name"],"extensions":{"code":"INTERNAL"}}]`
+	b, err := json.Marshal(result.Errors)
+	if err != nil {
+		t.Fatalf("Unexpected error marshalling errors: %v", err)
+	}
+	if string(b) != expectedJSON {
+		t.Fatalf("Unexpected JSON, expected %v, got %v", expectedJSON, string(b))
+	}
+}
+
+// concurrencyTracker records how many resolvers run at the same time.
+type concurrencyTracker struct {
+	mutex     sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (c *concurrencyTracker) resolve(value interface{}) interface{} {
+	c.mutex.Lock()
+	c.active++
+	if c.active > c.maxActive {
+		c.maxActive = c.active
+	}
+	c.mutex.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	c.mutex.Lock()
+	c.active--
+	c.mutex.Unlock()
+	return value
+}
+
+func newConcurrencyTestSchema(t *testing.T, tracker *concurrencyTracker) graphql.Schema {
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.FieldConfigMap{
+			"name": &graphql.FieldConfig{
+				Type: graphql.String,
+				Resolve: func(p graphql.GQLFRParams) interface{} {
+					return tracker.resolve(p.Source)
+				},
+			},
+			"fail": &graphql.FieldConfig{
+				Type: graphql.String,
+				ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+					tracker.resolve(nil)
+					return nil, fmt.Errorf("Error getting %v", p.Source)
+				},
+			},
+		},
+	})
+	fields := graphql.FieldConfigMap{
+		"items": &graphql.FieldConfig{
+			Type: graphql.NewList(itemType),
+			Resolve: func(p graphql.GQLFRParams) interface{} {
+				return []interface{}{"a", "b", "c", "d", "e", "f"}
+			},
+		},
+	}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		name := name
+		fields[name] = &graphql.FieldConfig{
+			Type: graphql.String,
+			Resolve: func(p graphql.GQLFRParams) interface{} {
+				return tracker.resolve(name)
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: fields,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return schema
+}
+
+func TestResolvesSiblingFieldsConcurrently(t *testing.T) {
+
+	tracker := &concurrencyTracker{}
+	schema := newConcurrencyTestSchema(t, tracker)
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "a",
+			"b": "b",
+			"c": "c",
+			"d": "d",
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema:      schema,
+		AST:         testutil.TestParse(t, `{ a, b, c, d }`),
+		Concurrency: 4,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if tracker.maxActive != 4 {
+		t.Fatalf("Expected 4 fields to be resolved concurrently, got %v", tracker.maxActive)
+	}
+}
+
+func TestBoundsConcurrentlyResolvedListItems(t *testing.T) {
+
+	tracker := &concurrencyTracker{}
+	schema := newConcurrencyTestSchema(t, tracker)
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"name": "a"},
+				map[string]interface{}{"name": "b"},
+				map[string]interface{}{"name": "c"},
+				map[string]interface{}{"name": "d"},
+				map[string]interface{}{"name": "e"},
+				map[string]interface{}{"name": "f"},
+			},
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema:      schema,
+		AST:         testutil.TestParse(t, `{ items { name } }`),
+		Concurrency: 3,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if tracker.maxActive < 2 || tracker.maxActive > 3 {
+		t.Fatalf("Expected at most 3 list items to be resolved concurrently, got %v", tracker.maxActive)
+	}
+}
+
+func TestResolvesFieldsSequentiallyByDefault(t *testing.T) {
+
+	tracker := &concurrencyTracker{}
+	schema := newConcurrencyTestSchema(t, tracker)
+
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `{ a, b, items { name } }`),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if tracker.maxActive != 1 {
+		t.Fatalf("Expected fields to be resolved one at a time, got %v", tracker.maxActive)
+	}
+}
+
+func TestCollectsErrorsOfConcurrentlyResolvedFields(t *testing.T) {
+
+	tracker := &concurrencyTracker{}
+	schema := newConcurrencyTestSchema(t, tracker)
+
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema:      schema,
+		AST:         testutil.TestParse(t, `{ items { name, fail } }`),
+		Concurrency: 8,
+	})
+	messages := []string{}
+	for _, err := range result.Errors {
+		messages = append(messages, err.Message)
+	}
+	sort.Strings(messages)
+	expectedMessages := []string{
+		"Error getting a", "Error getting b", "Error getting c",
+		"Error getting d", "Error getting e", "Error getting f",
+	}
+	if !reflect.DeepEqual(expectedMessages, messages) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedMessages, messages))
+	}
+	items := result.Data.(map[string]interface{})["items"].([]interface{})
+	for i, item := range items {
+		item := item.(map[string]interface{})
+		if item["name"] != expectedMessages[i][len("Error getting "):] || item["fail"] != nil {
+			t.Fatalf("Unexpected item %v: %v", i, item)
+		}
+	}
+}
+
+func TestWaitsForConcurrentlyResolvedFieldsWhenANonNullFieldFails(t *testing.T) {
+
+	var mutex sync.Mutex
+	slowResolved := false
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"slow": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						time.Sleep(50 * time.Millisecond)
+						mutex.Lock()
+						slowResolved = true
+						mutex.Unlock()
+						return "slow"
+					},
+				},
+				"bad": &graphql.FieldConfig{
+					Type: graphql.NewNonNull(graphql.String),
+					ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+						return nil, errors.New("Error getting bad")
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// slow is resolved by the only worker while bad fails in the calling
+	// goroutine, which must still wait for slow before returning
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema:      schema,
+		AST:         testutil.TestParse(t, `{ slow bad }`),
+		Concurrency: 2,
+	})
+	mutex.Lock()
+	resolved := slowResolved
+	mutex.Unlock()
+	if !resolved {
+		t.Fatalf("Expected the execution to wait for slow to be resolved")
+	}
+	if result.Data != nil || len(result.Errors) != 1 || result.Errors[0].Message != "Error getting bad" {
+		t.Fatalf("Unexpected result: %v", result)
+	}
+}
+
 func TestUsesTheInlineOperationIfNoOperationIsProvided(t *testing.T) {
 
 	doc := `{ a }`
@@ -793,15 +1434,71 @@ func TestCorrectFieldOrderingDespiteExecutionOrder(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 
-	// TODO: test to ensure key ordering
-	// The following does not work
-	// - iterating over result.Data map
-	//   Note that golang's map iteration order is randomized
-	//   So, iterating over result.Data won't do it for a test
-	// - Marshal the result.Data to json string and assert it
-	//   json.Marshal seems to re-sort the keys automatically
-	//
-	t.Skipf("TODO: Ensure key ordering")
+	// the data itself keeps the requested order
+	orderedResult := graphql.Execute(ep)
+	b, err := json.Marshal(orderedResult.Data)
+	if err != nil {
+		t.Fatalf("Failed to marshal result data: %v", err)
+	}
+	expectedJSON := `{"b":"b","a":"a","c":"c","d":"d","e":"e"}`
+	if string(b) != expectedJSON {
+		t.Fatalf("Unexpected result, expected %v, got %v", expectedJSON, string(b))
+	}
+}
+
+func TestPreservesKeyOrderingOfNestedObjectsAndFragments(t *testing.T) {
+	doc := `
+      {
+        z
+        deep { y z ...Frag }
+        a
+        ... on Type { m a }
+      }
+      fragment Frag on Type { w z x: a }
+    `
+	var typeObject *graphql.Object
+	typeObject = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Type",
+		Fields: graphql.FieldConfigMap{
+			"a": &graphql.FieldConfig{Type: graphql.String},
+			"m": &graphql.FieldConfig{Type: graphql.String},
+			"w": &graphql.FieldConfig{Type: graphql.String},
+			"y": &graphql.FieldConfig{Type: graphql.String},
+			"z": &graphql.FieldConfig{Type: graphql.String},
+		},
+	})
+	typeObject.AddFieldConfig("deep", &graphql.FieldConfig{
+		Type: typeObject,
+		Resolve: func(p graphql.GQLFRParams) interface{} {
+			return p.Source
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: typeObject,
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	data := map[string]interface{}{
+		"a": "a", "m": "m", "w": "w", "y": "y", "z": "z",
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, doc),
+		Root:   data,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Failed to marshal result: %v", err)
+	}
+	expectedJSON := `{"data":{"z":"z","deep":{"y":"y","z":"z","w":"w","x":"a"},"a":"a","m":"m"}}`
+	if string(b) != expectedJSON {
+		t.Fatalf("Unexpected result, expected %v, got %v", expectedJSON, string(b))
+	}
 }
 
 func TestAvoidsRecursion(t *testing.T) {
@@ -1004,6 +1701,7 @@ func TestFailsWhenAnIsTypeOfCheckIsNotMet(t *testing.T) {
 			gqlerrors.FormattedError{
 				Message:   `Expected value of type "SpecialType" but got: graphql_test.testNotSpecialType.`,
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"specials", 1},
 			},
 		},
 	}
@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"context"
+)
+
+/**
+ * Extension contributes an entry to the extensions of the results, see
+ * ExecuteParams.Extensions.
+ *
+ * Once an operation is executed, Execute calls the Contribute method of each
+ * extension with the params and result of the execution, and sets the
+ * returned value in Result.Extensions under the name of the extension, unless
+ * it is nil. The context of the params is never nil.
+ *
+ * Result.Extensions is marshalled to JSON with its keys sorted, values should
+ * be maps, OrderedMaps or structs so that the JSON output is deterministic.
+ */
+type Extension interface {
+	Name() string
+	Contribute(p ExecuteParams, result *Result) interface{}
+}
+
+// SetExtension sets the value of the extension entry of the result.
+func (r *Result) SetExtension(name string, value interface{}) {
+	if r.Extensions == nil {
+		r.Extensions = map[string]interface{}{}
+	}
+	r.Extensions[name] = value
+}
+
+// contributeExtensions sets the entries of the extensions in the result.
+func contributeExtensions(extensions []Extension, p ExecuteParams, result *Result) {
+	if p.Context == nil {
+		p.Context = context.Background()
+	}
+	for _, extension := range extensions {
+		if value := extension.Contribute(p, result); value != nil {
+			result.SetExtension(extension.Name(), value)
+		}
+	}
+}
+
+// ComplexityExtensionName is the name of the ComplexityExtension entry.
+const ComplexityExtensionName = "complexity"
+
+/**
+ * ComplexityExtension contributes the OperationComplexity of the executed
+ * operation, see AnalyzeComplexity. The complexity measured while checking
+ * the MaxDepth and MaxComplexity of the execution is reused, the operation is
+ * only analyzed again when the execution has no limits.
+ */
+type ComplexityExtension struct{}
+
+func (ComplexityExtension) Name() string {
+	return ComplexityExtensionName
+}
+
+func (ComplexityExtension) Contribute(p ExecuteParams, result *Result) interface{} {
+	if p.complexity != nil {
+		return *p.complexity
+	}
+	complexity, err := AnalyzeComplexity(p)
+	if err != nil {
+		return nil
+	}
+	return complexity
+}
@@ -0,0 +1,161 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type cacheHintsKey struct{}
+
+// cacheHintsExtension contributes the cache hints recorded by the resolvers
+// in the context of the request.
+type cacheHintsExtension struct{}
+
+func (cacheHintsExtension) Name() string {
+	return "cacheControl"
+}
+
+func (cacheHintsExtension) Contribute(p graphql.ExecuteParams, result *graphql.Result) interface{} {
+	hints, _ := p.Context.Value(cacheHintsKey{}).(map[string]interface{})
+	if len(hints) == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"version": 1,
+		"hints":   hints,
+	}
+}
+
+type errorCountExtension struct{}
+
+func (errorCountExtension) Name() string {
+	return "errorCount"
+}
+
+func (errorCountExtension) Contribute(p graphql.ExecuteParams, result *graphql.Result) interface{} {
+	return len(result.Errors)
+}
+
+func TestExtensions_ContributeEntriesToTheResult(t *testing.T) {
+	hints := map[string]interface{}{}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"cached": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						p.Context.Value(cacheHintsKey{}).(map[string]interface{})["cached"] = 60
+						return "cached value"
+					},
+				},
+				"failing": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						panic("failing")
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := graphql.Graphql(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ cached failing }`,
+		Context:       context.WithValue(context.Background(), cacheHintsKey{}, hints),
+		Extensions: []graphql.Extension{
+			errorCountExtension{},
+			cacheHintsExtension{},
+			graphql.ComplexityExtension{},
+		},
+	})
+	expected := map[string]interface{}{
+		"errorCount": 1,
+		"cacheControl": map[string]interface{}{
+			"version": 1,
+			"hints":   map[string]interface{}{"cached": 60},
+		},
+		"complexity": graphql.OperationComplexity{Depth: 1, Complexity: 2},
+	}
+	if !reflect.DeepEqual(expected, result.Extensions) {
+		t.Fatalf("Unexpected extensions, Diff: %v", testutil.Diff(expected, result.Extensions))
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedJSON := `{"data":{"cached":"cached value","failing":null},` +
+		`"errors":[{"message":"failing","locations":[{"line":1,"column":10}],"path":["failing"]}],` +
+		`"extensions":{"cacheControl":{"hints":{"cached":60},"version":1},` +
+		`"complexity":{"depth":1,"complexity":2},"errorCount":1}}`
+	if string(b) != expectedJSON {
+		t.Fatalf("Unexpected JSON: %s", b)
+	}
+}
+
+func TestExtensions_AreOmittedWithoutEntries(t *testing.T) {
+	result := graphql.Graphql(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: `{ hero { name } }`,
+		Extensions:    []graphql.Extension{cacheHintsExtension{}},
+	})
+	if result.Extensions != nil {
+		t.Fatalf("Unexpected extensions: %v", result.Extensions)
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := `{"data":{"hero":{"name":"R2-D2"}}}`; string(b) != expected {
+		t.Fatalf("Unexpected JSON: %s", b)
+	}
+}
+
+func TestExtensions_ComplexityIsMeasuredOnceWithLimits(t *testing.T) {
+	measured := 0
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"expensive": &graphql.FieldConfig{
+					Type: graphql.String,
+					Cost: func(args map[string]interface{}) int {
+						measured++
+						return 5
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	for _, maxComplexity := range []int{0, 10} {
+		measured = 0
+		result := graphql.Graphql(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ expensive }`,
+			MaxComplexity: maxComplexity,
+			Extensions:    []graphql.Extension{graphql.ComplexityExtension{}},
+		})
+		expected := map[string]interface{}{
+			"complexity": graphql.OperationComplexity{Depth: 1, Complexity: 5},
+		}
+		if !reflect.DeepEqual(expected, result.Extensions) {
+			t.Fatalf("Unexpected extensions, Diff: %v", testutil.Diff(expected, result.Extensions))
+		}
+		if measured != 1 {
+			t.Fatalf("Expected the operation to be measured once with a maximum complexity of %v, got %v", maxComplexity, measured)
+		}
+	}
+}
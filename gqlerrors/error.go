@@ -15,6 +15,11 @@ type Error struct {
 	Source    *source.Source
 	Positions []int
 	Locations []location.SourceLocation
+	// Path holds the response keys and list indices leading to the field
+	// the error occurred at, when raised during execution.
+	Path []interface{}
+	// OriginalError is the error the located error was created from, if any.
+	OriginalError error
 }
 
 // implements Golang's built-in `error` interface
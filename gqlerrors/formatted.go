@@ -7,14 +7,29 @@ import (
 )
 
 type FormattedError struct {
-	Message   string
-	Locations []location.SourceLocation
+	Message   string                    `json:"message"`
+	Locations []location.SourceLocation `json:"locations,omitempty"`
+	// Path holds the response keys and list indices leading to the field
+	// the error occurred at, it is empty for errors raised outside of the
+	// execution of a field.
+	Path []interface{} `json:"path,omitempty"`
+	// Extensions holds additional entries reported along with the error,
+	// such as an error code.
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
 }
 
 func (g FormattedError) Error() string {
 	return g.Message
 }
 
+// ExtendedError is implemented by errors carrying extensions, which are
+// reported in the extensions of the formatted error. Resolvers may return or
+// panic with such an error to attach an error code or other details.
+type ExtendedError interface {
+	error
+	Extensions() map[string]interface{}
+}
+
 func NewFormattedError(message string) FormattedError {
 	err := errors.New(message)
 	return FormatError(err)
@@ -26,18 +41,23 @@ func FormatError(err error) FormattedError {
 		return err
 	case *Error:
 		return FormattedError{
-			Message:   err.Error(),
-			Locations: err.Locations,
+			Message:    err.Error(),
+			Locations:  err.Locations,
+			Path:       err.Path,
+			Extensions: extensionsOf(err.OriginalError),
 		}
 	case Error:
 		return FormattedError{
-			Message:   err.Error(),
-			Locations: err.Locations,
+			Message:    err.Error(),
+			Locations:  err.Locations,
+			Path:       err.Path,
+			Extensions: extensionsOf(err.OriginalError),
 		}
 	default:
 		return FormattedError{
-			Message:   err.Error(),
-			Locations: []location.SourceLocation{},
+			Message:    err.Error(),
+			Locations:  []location.SourceLocation{},
+			Extensions: extensionsOf(err),
 		}
 	}
 }
@@ -49,3 +69,13 @@ func FormatErrors(errs ...error) []FormattedError {
 	}
 	return formattedErrors
 }
+
+func extensionsOf(err error) map[string]interface{} {
+	switch err := err.(type) {
+	case ExtendedError:
+		return err.Extensions()
+	case FormattedError:
+		return err.Extensions
+	}
+	return nil
+}
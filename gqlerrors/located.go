@@ -13,13 +13,17 @@ func NewLocatedError(err interface{}, nodes []ast.Node) *Error {
 		message = err
 	}
 	stack := message
-	return NewError(
+	located := NewError(
 		message,
 		nodes,
 		stack,
 		nil,
 		[]int{},
 	)
+	if err, ok := err.(error); ok {
+		located.OriginalError = err
+	}
+	return located
 }
 
 func FieldASTsToNodeASTs(fieldASTs []*ast.Field) []ast.Node {
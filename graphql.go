@@ -1,7 +1,10 @@
 package graphql
 
 import (
+	"context"
+
 	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/source"
 )
@@ -12,24 +15,78 @@ type Params struct {
 	RootObject     map[string]interface{}
 	VariableValues map[string]interface{}
 	OperationName  string
+
+	// Context is passed to every field resolver, see ExecuteParams.Context.
+	Context context.Context
+
+	// Concurrency enables concurrent resolution of fields, see
+	// ExecuteParams.Concurrency.
+	Concurrency int
+
+	// MaxDepth and MaxComplexity limit the operations to execute, see
+	// ExecuteParams.MaxDepth and ExecuteParams.MaxComplexity.
+	MaxDepth      int
+	MaxComplexity int
+
+	// ValidationRules are the rules the request document is validated
+	// against before execution, SpecifiedRules when not set.
+	ValidationRules []ValidationRule
+	// ValidationRulesKey names the ValidationRules in the DocumentCache, the
+	// documents validated with rules other than SpecifiedRules are only
+	// cached when it is set. Rule sets which may validate differently must
+	// have distinct keys.
+	ValidationRulesKey string
+
+	// PersistedQueries stores the queries of the persisted query hashes.
+	PersistedQueries PersistedQueryStore
+	// PersistedQueryHash is the sha256Hash of the persistedQuery extension of
+	// the request, see resolvePersistedQuery.
+	PersistedQueryHash string
+	// AllowlistOnly refuses the request strings which are not stored in
+	// PersistedQueries, rather than storing them.
+	AllowlistOnly bool
+
+	// Middlewares wrap the resolve function of every field, see
+	// ExecuteParams.Middlewares.
+	Middlewares []FieldMiddleware
+
+	// DocumentCache keeps the parsed and validated documents of the request
+	// strings, which are parsed and validated for each request when it is nil.
+	DocumentCache *DocumentCache
+
+	// Instrumentation observes the parsing, validation and execution of the
+	// request, and the resolution of every field.
+	Instrumentation Instrumentation
+
+	// Extensions contribute entries to the extensions of the result, see
+	// ExecuteParams.Extensions.
+	Extensions []Extension
 }
 
-func Graphql(p Params) *Result {
-	source := source.NewSource(&source.Source{
-		Body: p.RequestString,
-		Name: "GraphQL request",
-	})
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
-	if err != nil {
-		return &Result{
-			Errors: gqlerrors.FormatErrors(err),
+func Graphql(p Params) (result *Result) {
+	if p.Instrumentation != nil {
+		if p.Context == nil {
+			p.Context = context.Background()
+		}
+		var finish func(result *Result)
+		p.Context, finish = p.Instrumentation.InstrumentRequest(p.Context, p)
+		if finish != nil {
+			defer func() {
+				finish(result)
+			}()
 		}
 	}
-	validationResult := ValidateDocument(p.Schema, AST)
 
-	if !validationResult.IsValid {
+	requestString, persistedQueryErr := resolvePersistedQuery(p)
+	if persistedQueryErr != nil {
 		return &Result{
-			Errors: validationResult.Errors,
+			Errors: []gqlerrors.FormattedError{*persistedQueryErr},
+		}
+	}
+	AST, errs := parseAndValidate(p, requestString)
+	if len(errs) > 0 {
+		return &Result{
+			Errors: errs,
 		}
 	}
 
@@ -39,5 +96,72 @@ func Graphql(p Params) *Result {
 		AST:           AST,
 		OperationName: p.OperationName,
 		Args:          p.VariableValues,
+		Context:       p.Context,
+		Concurrency:   p.Concurrency,
+		MaxDepth:      p.MaxDepth,
+		MaxComplexity: p.MaxComplexity,
+		Middlewares:   p.Middlewares,
+
+		Instrumentation: p.Instrumentation,
+		Extensions:      p.Extensions,
+	})
+}
+
+// parseAndValidate returns the document of the request string, or its syntax
+// or validation errors, from the document cache of the params when set.
+func parseAndValidate(p Params, requestString string) (*ast.Document, []gqlerrors.FormattedError) {
+	validationRules := p.ValidationRules
+	if validationRules == nil {
+		validationRules = SpecifiedRules
+	}
+	cache := p.DocumentCache
+	rulesKey, cacheable := validationRulesKey(p)
+	if !cacheable {
+		cache = nil
+	}
+	if cache != nil {
+		if entry, ok := cache.get(p.Schema, rulesKey, requestString); ok {
+			return entry.document, entry.errors
+		}
+	}
+
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var finish func(errs []gqlerrors.FormattedError)
+	if p.Instrumentation != nil {
+		finish = p.Instrumentation.InstrumentParse(ctx, requestString)
+	}
+	source := source.NewSource(&source.Source{
+		Body: requestString,
+		Name: "GraphQL request",
 	})
+	var errs []gqlerrors.FormattedError
+	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	if err != nil {
+		errs = gqlerrors.FormatErrors(err)
+	}
+	if finish != nil {
+		finish(errs)
+	}
+
+	if err == nil {
+		finish = nil
+		if p.Instrumentation != nil {
+			finish = p.Instrumentation.InstrumentValidate(ctx, AST)
+		}
+		validationResult := ValidateDocumentWithRules(p.Schema, AST, validationRules)
+		if !validationResult.IsValid {
+			errs = validationResult.Errors
+		}
+		if finish != nil {
+			finish(errs)
+		}
+	}
+
+	if cache != nil {
+		cache.add(p.Schema, rulesKey, requestString, AST, errs)
+	}
+	return AST, errs
 }
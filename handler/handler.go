@@ -0,0 +1,570 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+const (
+	ContentTypeJSON           = "application/json"
+	ContentTypeGraphQL        = "application/graphql"
+	ContentTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	ContentTypeMultipartForm  = "multipart/form-data"
+)
+
+// maxMultipartMemory is the size of the multipart form bodies kept in memory.
+const maxMultipartMemory = 32 << 20
+
+// RootObjectFn returns the root object of the execution of a request.
+type RootObjectFn func(r *http.Request) map[string]interface{}
+
+// ContextFn returns the context of the execution of a request, which is
+// passed to every field resolver.
+type ContextFn func(r *http.Request) context.Context
+
+// Config configures a Handler.
+type Config struct {
+	Schema *graphql.Schema
+	// Pretty indents the JSON responses.
+	Pretty bool
+	// RootObjectFn builds the root object of each request, there is no root
+	// object when it is nil.
+	RootObjectFn RootObjectFn
+	// ContextFn builds the context of each request, the context of the
+	// request itself is used when it is nil.
+	ContextFn ContextFn
+	// MaxBodySize is the maximum size in bytes of the request bodies, larger
+	// bodies are answered with 413 Request Entity Too Large. Bodies of any
+	// size are accepted when it is 0.
+	MaxBodySize int64
+	// MaxBatchSize is the maximum number of operations of a batched request,
+	// batches of any size are accepted when it is 0.
+	MaxBatchSize int
+	// BatchConcurrency is the maximum number of operations of a batched
+	// request executed at the same time, they are executed one at a time when
+	// it is 0 or 1. RootObjectFn and ContextFn must then be safe for concurrent
+	// use.
+	BatchConcurrency int
+	// Concurrency is the maximum number of fields of an operation resolved
+	// at the same time, see graphql.Params.Concurrency.
+	Concurrency int
+	// MaxDepth and MaxComplexity limit the operations to execute, see
+	// graphql.Params.MaxDepth and graphql.Params.MaxComplexity.
+	MaxDepth      int
+	MaxComplexity int
+	// ValidationRules are the rules the documents are validated against,
+	// graphql.SpecifiedRules when not set.
+	ValidationRules []graphql.ValidationRule
+	// ValidationRulesKey names the ValidationRules in the DocumentCache, see
+	// graphql.Params.ValidationRulesKey.
+	ValidationRulesKey string
+	// Middlewares wrap the resolve function of every field, see
+	// graphql.Params.Middlewares.
+	Middlewares []graphql.FieldMiddleware
+	// PersistedQueries stores the queries of the automatic persisted query
+	// protocol, which is not supported when it is nil. Unless AllowlistOnly
+	// is set, clients add queries to it, see graphql.NewLRUPersistedQueryStore
+	// for a store of bounded size.
+	PersistedQueries graphql.PersistedQueryStore
+	// AllowlistOnly refuses the queries which are not stored in
+	// PersistedQueries.
+	AllowlistOnly bool
+	// DocumentCache keeps the parsed and validated documents of the queries,
+	// see graphql.Params.DocumentCache.
+	DocumentCache *graphql.DocumentCache
+	// Instrumentation observes the requests, see graphql.Params.Instrumentation.
+	Instrumentation graphql.Instrumentation
+	// Extensions contribute entries to the extensions of the results, see
+	// graphql.Params.Extensions.
+	Extensions []graphql.Extension
+}
+
+// DefaultMaxBodySize is the MaxBodySize of the config returned by NewConfig.
+const DefaultMaxBodySize = 1 << 20
+
+// NewConfig returns a default Config, with pretty JSON responses, bodies of
+// up to DefaultMaxBodySize bytes and batches of up to 10 operations.
+func NewConfig() *Config {
+	return &Config{
+		Schema:       nil,
+		Pretty:       true,
+		MaxBodySize:  DefaultMaxBodySize,
+		MaxBatchSize: 10,
+	}
+}
+
+/**
+ * Handler serves the GraphQL requests of a schema over HTTP.
+ *
+ * GET requests carry the query, variables and operationName in the URL
+ * query, they may only perform query operations. POST requests carry them in
+ * a JSON, form or multipart form body, or carry the query alone in an
+ * application/graphql body, along with the URL query.
+ *
+ * Results are answered with 200 OK, unless the request could not be executed
+ * at all, which is answered with 400 Bad Request. Errors raised while
+ * resolving fields are answered with 200 OK, even when they null the whole
+ * data.
+ *
+ * Requests may carry the sha256Hash of a persisted query in their
+ * persistedQuery extension, see graphql.Params.PersistedQueryHash.
+ *
+ * A POST request with a JSON array body is a batch: each operation of the
+ * array is executed, and the array of their results, in the same order, is
+ * answered with 200 OK.
+ */
+type Handler struct {
+	Schema *graphql.Schema
+
+	pretty           bool
+	rootObjectFn     RootObjectFn
+	contextFn        ContextFn
+	maxBodySize      int64
+	maxBatchSize     int
+	batchConcurrency int
+	concurrency      int
+	maxDepth         int
+	maxComplexity    int
+	validationRules  []graphql.ValidationRule
+	rulesKey         string
+	middlewares      []graphql.FieldMiddleware
+	persistedQueries graphql.PersistedQueryStore
+	allowlistOnly    bool
+	documentCache    *graphql.DocumentCache
+	instrumentation  graphql.Instrumentation
+	extensions       []graphql.Extension
+}
+
+// New returns a Handler of the schema of the config, it panics without schema.
+func New(p *Config) *Handler {
+	if p == nil {
+		p = NewConfig()
+	}
+	if p.Schema == nil {
+		panic("undefined GraphQL schema")
+	}
+	return &Handler{
+		Schema:           p.Schema,
+		pretty:           p.Pretty,
+		rootObjectFn:     p.RootObjectFn,
+		contextFn:        p.ContextFn,
+		maxBodySize:      p.MaxBodySize,
+		maxBatchSize:     p.MaxBatchSize,
+		batchConcurrency: p.BatchConcurrency,
+		concurrency:      p.Concurrency,
+		maxDepth:         p.MaxDepth,
+		maxComplexity:    p.MaxComplexity,
+		validationRules:  p.ValidationRules,
+		rulesKey:         p.ValidationRulesKey,
+		middlewares:      p.Middlewares,
+		persistedQueries: p.PersistedQueries,
+		allowlistOnly:    p.AllowlistOnly,
+		documentCache:    p.DocumentCache,
+		instrumentation:  p.Instrumentation,
+		extensions:       p.Extensions,
+	}
+}
+
+// RequestOptions are the GraphQL parameters of a request.
+type RequestOptions struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+	Extensions    map[string]interface{} `json:"extensions"`
+}
+
+// persistedQueryHash returns the sha256Hash of the persistedQuery extension.
+func (opts *RequestOptions) persistedQueryHash() string {
+	persistedQuery, _ := opts.Extensions["persistedQuery"].(map[string]interface{})
+	hash, _ := persistedQuery["sha256Hash"].(string)
+	return hash
+}
+
+// requestError is an error answered with its status code.
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+func newRequestError(status int, message string) *requestError {
+	return &requestError{
+		status:  status,
+		message: message,
+	}
+}
+
+/**
+ * Reads the GraphQL parameters of the request, from its URL query, and from
+ * its body for POST requests. The parameters of the body take precedence.
+ */
+func NewRequestOptions(r *http.Request) (*RequestOptions, error) {
+	batch, batched, err := getRequestOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	if batched {
+		return nil, newRequestError(http.StatusBadRequest, "POST body sent a batch of operations.")
+	}
+	return batch[0], nil
+}
+
+/**
+ * Reads the GraphQL parameters of each operation of a batched request, whose
+ * body is a JSON array, or of the single operation of any other request.
+ */
+func NewBatchRequestOptions(r *http.Request) ([]*RequestOptions, error) {
+	batch, _, err := getRequestOptions(r)
+	return batch, err
+}
+
+// getRequestOptions reads the operations of the request, and whether they
+// were sent as a batch.
+func getRequestOptions(r *http.Request) ([]*RequestOptions, bool, error) {
+	opts, err := getFromForm(r.URL.Query())
+	if err != nil {
+		return nil, false, err
+	}
+	if r.Method != "POST" {
+		return []*RequestOptions{opts}, false, nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	mediaType := ContentTypeJSON
+	if contentType != "" {
+		mediaType, _, err = mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, false, newRequestError(http.StatusBadRequest, "Invalid Content-Type header.")
+		}
+	}
+
+	var bodyOpts *RequestOptions
+	switch mediaType {
+	case ContentTypeGraphQL:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, false, newRequestError(http.StatusBadRequest, "Unable to read the request body.")
+		}
+		bodyOpts = &RequestOptions{Query: string(body)}
+	case ContentTypeFormURLEncoded:
+		if err := r.ParseForm(); err != nil {
+			return nil, false, newRequestError(http.StatusBadRequest, "Unable to parse the form body.")
+		}
+		bodyOpts, err = getFromForm(r.PostForm)
+	case ContentTypeMultipartForm:
+		if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+			return nil, false, newRequestError(http.StatusBadRequest, "Unable to parse the multipart form body.")
+		}
+		bodyOpts, err = getFromForm(r.PostForm)
+	case ContentTypeJSON:
+		decoder := json.NewDecoder(r.Body)
+		var body json.RawMessage
+		if err := decoder.Decode(&body); err != nil {
+			return nil, false, newRequestError(http.StatusBadRequest, "POST body sent invalid JSON.")
+		}
+		// the body must hold a single JSON value
+		var trailing json.RawMessage
+		if err := decoder.Decode(&trailing); err != io.EOF {
+			return nil, false, newRequestError(http.StatusBadRequest, "POST body sent invalid JSON.")
+		}
+		if bytes.HasPrefix(body, []byte("[")) {
+			batch := []*RequestOptions{}
+			if err := json.Unmarshal(body, &batch); err != nil {
+				return nil, false, newRequestError(http.StatusBadRequest, "POST body sent invalid JSON.")
+			}
+			for i, opts := range batch {
+				if opts == nil {
+					batch[i] = &RequestOptions{}
+				}
+			}
+			return batch, true, nil
+		}
+		bodyOpts = &RequestOptions{}
+		if err := json.Unmarshal(body, bodyOpts); err != nil {
+			return nil, false, newRequestError(http.StatusBadRequest, "POST body sent invalid JSON.")
+		}
+	default:
+		return nil, false, newRequestError(http.StatusUnsupportedMediaType,
+			`Unsupported content type "`+mediaType+`".`)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if bodyOpts.Query != "" {
+		opts.Query = bodyOpts.Query
+	}
+	if bodyOpts.Variables != nil {
+		opts.Variables = bodyOpts.Variables
+	}
+	if bodyOpts.OperationName != "" {
+		opts.OperationName = bodyOpts.OperationName
+	}
+	if bodyOpts.Extensions != nil {
+		opts.Extensions = bodyOpts.Extensions
+	}
+	return []*RequestOptions{opts}, false, nil
+}
+
+func getFromForm(values url.Values) (*RequestOptions, error) {
+	opts := &RequestOptions{
+		Query:         values.Get("query"),
+		OperationName: values.Get("operationName"),
+	}
+	if variables := values.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &opts.Variables); err != nil {
+			return nil, newRequestError(http.StatusBadRequest, "Variables are invalid JSON.")
+		}
+	}
+	if extensions := values.Get("extensions"); extensions != "" {
+		if err := json.Unmarshal([]byte(extensions), &opts.Extensions); err != nil {
+			return nil, newRequestError(http.StatusBadRequest, "Extensions are invalid JSON.")
+		}
+	}
+	return opts, nil
+}
+
+// limitedBody is a request body read through http.MaxBytesReader, which
+// records whether the body was larger than the limit.
+type limitedBody struct {
+	io.ReadCloser
+	limit    int64
+	read     int64
+	exceeded bool
+}
+
+func (body *limitedBody) Read(p []byte) (int, error) {
+	n, err := body.ReadCloser.Read(p)
+	body.read += int64(n)
+	if err != nil && err != io.EOF && body.read >= body.limit {
+		body.exceeded = true
+	}
+	return n, err
+}
+
+// ServeHTTP executes the GraphQL request and writes its result as JSON.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		w.Header().Set("Allow", "GET, POST")
+		h.writeError(w, newRequestError(http.StatusMethodNotAllowed, "GraphQL only supports GET and POST requests."))
+		return
+	}
+
+	var body *limitedBody
+	if h.maxBodySize > 0 && r.Body != nil {
+		body = &limitedBody{
+			ReadCloser: http.MaxBytesReader(w, r.Body, h.maxBodySize),
+			limit:      h.maxBodySize,
+		}
+		r.Body = body
+	}
+	batch, batched, err := getRequestOptions(r)
+	if body != nil && body.exceeded {
+		err = newRequestError(http.StatusRequestEntityTooLarge, fmt.Sprintf(
+			"POST body exceeds the maximum size of %v bytes.", h.maxBodySize))
+	}
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if batched {
+		h.serveBatch(w, r, batch)
+		return
+	}
+
+	opts := batch[0]
+	if r.Method == "GET" && h.isMutation(opts) {
+		w.Header().Set("Allow", "POST")
+		h.writeError(w, newRequestError(http.StatusMethodNotAllowed,
+			"Can only perform a mutation operation from a POST request."))
+		return
+	}
+	result, err := h.execute(r, opts)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	status := http.StatusOK
+	if isRequestError(result) {
+		status = http.StatusBadRequest
+	}
+	h.writeJSON(w, status, result)
+}
+
+// isRequestError reports whether the errors of the result prevented its
+// execution: persisted query, syntax, validation, variable coercion and limit
+// errors. Errors raised while resolving fields are located by their path,
+// even when they null the whole data, and are not request errors.
+func isRequestError(result *graphql.Result) bool {
+	if result.Data != nil || !result.HasErrors() {
+		return false
+	}
+	for _, err := range result.Errors {
+		if err.Path != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// serveBatch executes the operations of a batched request, at most
+// batchConcurrency at a time, and writes the array of their results.
+func (h *Handler) serveBatch(w http.ResponseWriter, r *http.Request, batch []*RequestOptions) {
+	if len(batch) == 0 {
+		h.writeError(w, newRequestError(http.StatusBadRequest, "Must provide at least one operation."))
+		return
+	}
+	if h.maxBatchSize > 0 && len(batch) > h.maxBatchSize {
+		h.writeError(w, newRequestError(http.StatusBadRequest, fmt.Sprintf(
+			"Batch of %v operations exceeds the maximum batch size of %v.", len(batch), h.maxBatchSize)))
+		return
+	}
+
+	results := make([]*graphql.Result, len(batch))
+	executeAt := func(i int) {
+		result, err := h.execute(r, batch[i])
+		if err != nil {
+			result = &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+		}
+		results[i] = result
+	}
+	if h.batchConcurrency <= 1 {
+		for i := range batch {
+			executeAt(i)
+		}
+	} else {
+		workers := make(chan struct{}, h.batchConcurrency)
+		var wg sync.WaitGroup
+		for i := range batch {
+			wg.Add(1)
+			workers <- struct{}{}
+			go func(i int) {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+				executeAt(i)
+			}(i)
+		}
+		wg.Wait()
+	}
+	h.writeJSON(w, http.StatusOK, results)
+}
+
+// execute executes an operation of the request, it returns an error when the
+// operation cannot be executed at all.
+func (h *Handler) execute(r *http.Request, opts *RequestOptions) (*graphql.Result, error) {
+	if opts.Query == "" && opts.persistedQueryHash() == "" {
+		return nil, newRequestError(http.StatusBadRequest, "Must provide query string.")
+	}
+	return graphql.Graphql(h.newParams(r, opts)), nil
+}
+
+func (h *Handler) newParams(r *http.Request, opts *RequestOptions) graphql.Params {
+	ctx := r.Context()
+	if h.contextFn != nil {
+		ctx = h.contextFn(r)
+	}
+	var rootObject map[string]interface{}
+	if h.rootObjectFn != nil {
+		rootObject = h.rootObjectFn(r)
+	}
+	return graphql.Params{
+		Schema:         *h.Schema,
+		RequestString:  opts.Query,
+		RootObject:     rootObject,
+		VariableValues: opts.Variables,
+		OperationName:  opts.OperationName,
+		Context:        ctx,
+
+		Concurrency:        h.concurrency,
+		MaxDepth:           h.maxDepth,
+		MaxComplexity:      h.maxComplexity,
+		ValidationRules:    h.validationRules,
+		ValidationRulesKey: h.rulesKey,
+		Middlewares:        h.middlewares,
+
+		PersistedQueries:   h.persistedQueries,
+		PersistedQueryHash: opts.persistedQueryHash(),
+		AllowlistOnly:      h.allowlistOnly,
+		DocumentCache:      h.documentCache,
+		Instrumentation:    h.instrumentation,
+		Extensions:         h.extensions,
+	}
+}
+
+// isMutation reports whether the operation to execute is a mutation, the
+// documents which cannot be parsed are reported by the execution.
+func (h *Handler) isMutation(opts *RequestOptions) bool {
+	query := opts.Query
+	if query == "" && h.persistedQueries != nil {
+		query, _ = h.persistedQueries.Get(opts.persistedQueryHash())
+	}
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: query,
+			Name: "GraphQL request",
+		}),
+	})
+	if err != nil {
+		return false
+	}
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		name := ""
+		if operation.Name != nil {
+			name = operation.Name.Value
+		}
+		if opts.OperationName == "" || opts.OperationName == name {
+			return operation.Operation == "mutation"
+		}
+	}
+	return false
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	if err, ok := err.(*requestError); ok {
+		status = err.status
+	}
+	h.writeJSON(w, status, &graphql.Result{
+		Errors: gqlerrors.FormatErrors(err),
+	})
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	var buff []byte
+	var err error
+	if h.pretty {
+		buff, err = json.MarshalIndent(value, "", "\t")
+	} else {
+		buff, err = json.Marshal(value)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buff)
+}
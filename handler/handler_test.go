@@ -0,0 +1,608 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/handler"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type contextKey string
+
+func newTestSchema(t *testing.T) *graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"greeting": &graphql.FieldConfig{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{
+							Type:         graphql.String,
+							DefaultValue: "World",
+						},
+					},
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return "Hello " + p.Args["name"].(string)
+					},
+				},
+				"user": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return p.Context.Value(contextKey("user"))
+					},
+				},
+				"root": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						if root, ok := p.Source.(map[string]interface{}); ok {
+							return root["value"]
+						}
+						return nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.FieldConfigMap{
+				"writeGreeting": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return "written"
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return &schema
+}
+
+func serve(h http.Handler, r *http.Request) (int, http.Header, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		body = map[string]interface{}{"invalid": w.Body.String()}
+	}
+	return w.Code, w.Header(), body
+}
+
+func errorBody(message string) map[string]interface{} {
+	return map[string]interface{}{
+		"data": nil,
+		"errors": []interface{}{
+			map[string]interface{}{"message": message},
+		},
+	}
+}
+
+func TestHandler_ExecutesGETRequests(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: newTestSchema(t)})
+	values := url.Values{}
+	values.Set("query", `query A { greeting } query B($name: String) { greeting(name: $name) }`)
+	values.Set("variables", `{"name": "Luke"}`)
+	values.Set("operationName", "B")
+	r := httptest.NewRequest("GET", "/graphql?"+values.Encode(), nil)
+
+	status, header, body := serve(h, r)
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{"greeting": "Hello Luke"},
+	}
+	if status != http.StatusOK {
+		t.Fatalf("Unexpected status: %v", status)
+	}
+	if contentType := header.Get("Content-Type"); contentType != "application/json; charset=utf-8" {
+		t.Fatalf("Unexpected content type: %v", contentType)
+	}
+	if !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, body))
+	}
+}
+
+func TestHandler_ExecutesPOSTRequestsOfEachContentType(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: newTestSchema(t)})
+	query := `mutation M { writeGreeting } query Q($name: String) { greeting(name: $name) }`
+
+	jsonBody := `{"query": "` + query + `", "variables": {"name": "Leia"}, "operationName": "Q"}`
+
+	form := url.Values{}
+	form.Set("query", query)
+	form.Set("variables", `{"name": "Leia"}`)
+	form.Set("operationName", "Q")
+
+	multipartBody := &bytes.Buffer{}
+	writer := multipart.NewWriter(multipartBody)
+	writer.WriteField("query", query)
+	writer.WriteField("variables", `{"name": "Leia"}`)
+	writer.WriteField("operationName", "Q")
+	writer.Close()
+
+	graphqlQuery := url.Values{}
+	graphqlQuery.Set("variables", `{"name": "Leia"}`)
+	graphqlQuery.Set("operationName", "Q")
+
+	tests := []struct {
+		contentType string
+		target      string
+		body        string
+	}{
+		{"application/json", "/graphql", jsonBody},
+		{"", "/graphql", jsonBody},
+		{"application/x-www-form-urlencoded", "/graphql", form.Encode()},
+		{writer.FormDataContentType(), "/graphql", multipartBody.String()},
+		{"application/graphql; charset=utf-8", "/graphql?" + graphqlQuery.Encode(), query},
+	}
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{"greeting": "Hello Leia"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("POST", test.target, strings.NewReader(test.body))
+		if test.contentType != "" {
+			r.Header.Set("Content-Type", test.contentType)
+		}
+		status, _, body := serve(h, r)
+		if status != http.StatusOK {
+			t.Fatalf("Unexpected status for %v: %v", test.contentType, status)
+		}
+		if !reflect.DeepEqual(expected, body) {
+			t.Fatalf("Unexpected result for %v, Diff: %v", test.contentType, testutil.Diff(expected, body))
+		}
+	}
+}
+
+func TestHandler_AllowsMutationsOnlyOverPOST(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: newTestSchema(t)})
+
+	r := httptest.NewRequest("GET", "/graphql?query="+url.QueryEscape(`mutation M { writeGreeting }`), nil)
+	status, header, body := serve(h, r)
+	expected := errorBody("Can only perform a mutation operation from a POST request.")
+	if status != http.StatusMethodNotAllowed {
+		t.Fatalf("Unexpected status: %v", status)
+	}
+	if allow := header.Get("Allow"); allow != "POST" {
+		t.Fatalf("Unexpected Allow header: %v", allow)
+	}
+	if !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, body))
+	}
+
+	// the query of a document also holding a mutation is allowed
+	values := url.Values{}
+	values.Set("query", `mutation M { writeGreeting } query Q { greeting }`)
+	values.Set("operationName", "Q")
+	r = httptest.NewRequest("GET", "/graphql?"+values.Encode(), nil)
+	status, _, body = serve(h, r)
+	expected = map[string]interface{}{
+		"data": map[string]interface{}{"greeting": "Hello World"},
+	}
+	if status != http.StatusOK {
+		t.Fatalf("Unexpected status: %v", status)
+	}
+	if !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, body))
+	}
+
+	r = httptest.NewRequest("POST", "/graphql", strings.NewReader(`mutation M { writeGreeting }`))
+	r.Header.Set("Content-Type", "application/graphql")
+	status, _, body = serve(h, r)
+	expected = map[string]interface{}{
+		"data": map[string]interface{}{"writeGreeting": "written"},
+	}
+	if status != http.StatusOK {
+		t.Fatalf("Unexpected status: %v", status)
+	}
+	if !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, body))
+	}
+}
+
+func TestHandler_RejectsInvalidRequests(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema:      newTestSchema(t),
+		MaxBodySize: 64,
+	})
+	large := strings.Repeat(" ", 64)
+	tests := []struct {
+		method      string
+		target      string
+		contentType string
+		body        string
+		status      int
+		message     string
+	}{
+		{"PUT", "/graphql?query={greeting}", "", "", http.StatusMethodNotAllowed,
+			"GraphQL only supports GET and POST requests."},
+		{"GET", "/graphql", "", "", http.StatusBadRequest,
+			"Must provide query string."},
+		{"GET", "/graphql?query={greeting}&variables=nope", "", "", http.StatusBadRequest,
+			"Variables are invalid JSON."},
+		{"POST", "/graphql", "application/json", `{"query": `, http.StatusBadRequest,
+			"POST body sent invalid JSON."},
+		{"POST", "/graphql", "text/plain", `{greeting}`, http.StatusUnsupportedMediaType,
+			`Unsupported content type "text/plain".`},
+		{"POST", "/graphql", "application/graphql", `{ greeting(`, http.StatusBadRequest,
+			"Syntax Error GraphQL request (1:12) Expected Name, found EOF\n\n1: { greeting(\n              ^\n"},
+		{"POST", "/graphql", "application/json", `{"query": "{ greeting }"} garbage`, http.StatusBadRequest,
+			"POST body sent invalid JSON."},
+		{"POST", "/graphql", "application/json", `{"query": "{ greeting }"} {}`, http.StatusBadRequest,
+			"POST body sent invalid JSON."},
+		{"POST", "/graphql", "application/json", `{"query": "{` + large + `greeting }"}`, http.StatusRequestEntityTooLarge,
+			"POST body exceeds the maximum size of 64 bytes."},
+		{"POST", "/graphql", "application/graphql", `{` + large + `greeting }`, http.StatusRequestEntityTooLarge,
+			"POST body exceeds the maximum size of 64 bytes."},
+		{"POST", "/graphql", "application/x-www-form-urlencoded", `query=` + url.QueryEscape(`{`+large+`greeting }`),
+			http.StatusRequestEntityTooLarge, "POST body exceeds the maximum size of 64 bytes."},
+	}
+	for _, test := range tests {
+		var r *http.Request
+		if test.body == "" {
+			r = httptest.NewRequest(test.method, test.target, nil)
+		} else {
+			r = httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+		}
+		if test.contentType != "" {
+			r.Header.Set("Content-Type", test.contentType)
+		}
+		status, _, body := serve(h, r)
+		if status != test.status {
+			t.Fatalf("Unexpected status for %v %v: %v", test.method, test.target, status)
+		}
+		errs, _ := body["errors"].([]interface{})
+		if len(errs) != 1 {
+			t.Fatalf("Unexpected errors for %v %v: %v", test.method, test.target, body)
+		}
+		if message := errs[0].(map[string]interface{})["message"]; message != test.message {
+			t.Fatalf("Unexpected message for %v %v: %q", test.method, test.target, message)
+		}
+	}
+
+	// a body of exactly the maximum size, followed by blanks only, is served
+	query := `{"query": "{ greeting }"}`
+	r := httptest.NewRequest("POST", "/graphql", strings.NewReader(query+strings.Repeat("\n", 64-len(query))))
+	status, _, body := serve(h, r)
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{"greeting": "Hello World"},
+	}
+	if status != http.StatusOK || !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected response: %v %v", status, body)
+	}
+}
+
+func TestHandler_AnswersExecutionErrorsWithOK(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"required": &graphql.FieldConfig{
+					Type: graphql.NewNonNull(graphql.String),
+					Args: graphql.FieldConfigArgument{
+						"fail": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					},
+					ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+						if fail, _ := p.Args["fail"].(bool); fail {
+							return nil, errors.New("Unable to resolve required.")
+						}
+						return "required", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		ctx     context.Context
+		query   string
+		status  int
+		message string
+	}{
+		{nil, `{ required(fail: true) }`, http.StatusOK, "Unable to resolve required."},
+		{cancelled, `{ required }`, http.StatusOK, "context canceled"},
+		{nil, `{ required(`, http.StatusBadRequest, "Syntax Error GraphQL request (1:12) Expected Name, found EOF\n\n1: { required(\n              ^\n"},
+		{nil, `{ unknown }`, http.StatusBadRequest, `Cannot query field "unknown" on "Query".`},
+		{nil, `query Q($fail: Boolean!) { required(fail: $fail) }`, http.StatusBadRequest,
+			`Variable "$fail" of required type "Boolean!" was not provided.`},
+	}
+	for _, test := range tests {
+		ctx := test.ctx
+		h := handler.New(&handler.Config{
+			Schema: &schema,
+			ContextFn: func(r *http.Request) context.Context {
+				if ctx != nil {
+					return ctx
+				}
+				return r.Context()
+			},
+		})
+		r := httptest.NewRequest("POST", "/graphql", strings.NewReader(test.query))
+		r.Header.Set("Content-Type", "application/graphql")
+		status, _, body := serve(h, r)
+		errs, _ := body["errors"].([]interface{})
+		if status != test.status || body["data"] != nil || len(errs) != 1 {
+			t.Fatalf("Unexpected response for %v: %v %v", test.query, status, body)
+		}
+		if message := errs[0].(map[string]interface{})["message"]; message != test.message {
+			t.Fatalf("Unexpected error for %v: %v", test.query, message)
+		}
+	}
+}
+
+func TestHandler_BuildsTheRootObjectAndContextOfEachRequest(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: newTestSchema(t),
+		RootObjectFn: func(r *http.Request) map[string]interface{} {
+			return map[string]interface{}{"value": r.Header.Get("X-Root")}
+		},
+		ContextFn: func(r *http.Request) context.Context {
+			return context.WithValue(r.Context(), contextKey("user"), r.Header.Get("X-User"))
+		},
+	})
+	r := httptest.NewRequest("GET", "/graphql?query="+url.QueryEscape(`{ user root }`), nil)
+	r.Header.Set("X-Root", "root value")
+	r.Header.Set("X-User", "Han")
+
+	status, _, body := serve(h, r)
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{
+			"user": "Han",
+			"root": "root value",
+		},
+	}
+	if status != http.StatusOK {
+		t.Fatalf("Unexpected status: %v", status)
+	}
+	if !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, body))
+	}
+}
+
+func TestHandler_ExecutesBatchedRequestsInOrder(t *testing.T) {
+	for _, concurrency := range []int{0, 3} {
+		h := handler.New(&handler.Config{
+			Schema:           newTestSchema(t),
+			MaxBatchSize:     4,
+			BatchConcurrency: concurrency,
+		})
+		body := ` [
+			{"query": "query Q($name: String) { greeting(name: $name) }", "variables": {"name": "Luke"}},
+			{"query": "query A { greeting } query B { root }", "operationName": "A"},
+			{"query": "mutation M { writeGreeting }"},
+			{"variables": {"name": "Leia"}}
+		]`
+		r := httptest.NewRequest("POST", "/graphql", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		var results []interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+			t.Fatalf("Unexpected response: %v", w.Body.String())
+		}
+		expected := []interface{}{
+			map[string]interface{}{
+				"data": map[string]interface{}{"greeting": "Hello Luke"},
+			},
+			map[string]interface{}{
+				"data": map[string]interface{}{"greeting": "Hello World"},
+			},
+			map[string]interface{}{
+				"data": map[string]interface{}{"writeGreeting": "written"},
+			},
+			errorBody("Must provide query string."),
+		}
+		if w.Code != http.StatusOK {
+			t.Fatalf("Unexpected status: %v", w.Code)
+		}
+		if !reflect.DeepEqual(expected, results) {
+			t.Fatalf("Unexpected results with concurrency %v, Diff: %v", concurrency, testutil.Diff(expected, results))
+		}
+	}
+}
+
+func TestHandler_RejectsInvalidBatches(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema:       newTestSchema(t),
+		MaxBatchSize: 2,
+	})
+	tests := []struct {
+		body    string
+		message string
+	}{
+		{`[]`, "Must provide at least one operation."},
+		{`[{"query": "{ greeting }"}, {"query": "{ greeting }"}, {"query": "{ greeting }"}]`,
+			"Batch of 3 operations exceeds the maximum batch size of 2."},
+		{`[{"query": 1}]`, "POST body sent invalid JSON."},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("POST", "/graphql", strings.NewReader(test.body))
+		status, _, body := serve(h, r)
+		expected := errorBody(test.message)
+		if status != http.StatusBadRequest {
+			t.Fatalf("Unexpected status for %v: %v", test.body, status)
+		}
+		if !reflect.DeepEqual(expected, body) {
+			t.Fatalf("Unexpected result for %v, Diff: %v", test.body, testutil.Diff(expected, body))
+		}
+	}
+
+	r := httptest.NewRequest("POST", "/graphql", strings.NewReader(`[{"query": "{ greeting }"}]`))
+	if _, err := handler.NewRequestOptions(r); err == nil {
+		t.Fatalf("Expected an error reading a single operation from a batch")
+	}
+}
+
+// noRootRule is a custom rule which forbids selecting the root field.
+func noRootRule(context *graphql.ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Field); ok && node.Name.Value == "root" {
+						context.ReportError(graphql.NewLocatedError(`Field "root" is not allowed.`, []ast.Node{node}))
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+func TestHandler_AppliesTheExecutionLimitsAndValidationRules(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema:          newTestSchema(t),
+		Concurrency:     2,
+		MaxDepth:        1,
+		MaxComplexity:   2,
+		ValidationRules: append([]graphql.ValidationRule{noRootRule}, graphql.SpecifiedRules...),
+	})
+	messages := func(result interface{}) []interface{} {
+		messages := []interface{}{}
+		errors, _ := result.(map[string]interface{})["errors"].([]interface{})
+		for _, err := range errors {
+			messages = append(messages, err.(map[string]interface{})["message"])
+		}
+		return messages
+	}
+
+	r := httptest.NewRequest("POST", "/graphql", strings.NewReader(`{ a: greeting, b: greeting, c: greeting }`))
+	r.Header.Set("Content-Type", "application/graphql")
+	status, _, body := serve(h, r)
+	expected := []interface{}{"Operation has a complexity of at least 3, which exceeds the maximum complexity of 2."}
+	if status != http.StatusBadRequest {
+		t.Fatalf("Unexpected status: %v", status)
+	}
+	if !reflect.DeepEqual(expected, messages(body)) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, messages(body)))
+	}
+
+	r = httptest.NewRequest("POST", "/graphql", strings.NewReader(` [
+		{"query": "{ a: greeting, b: greeting }"},
+		{"query": "{ a: greeting, b: greeting, c: greeting }"},
+		{"query": "{ root }"}
+	]`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	var results []interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil || len(results) != 3 {
+		t.Fatalf("Unexpected response: %v", w.Body.String())
+	}
+	expectedData := map[string]interface{}{"a": "Hello World", "b": "Hello World"}
+	if data := results[0].(map[string]interface{})["data"]; !reflect.DeepEqual(expectedData, data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedData, data))
+	}
+	if !reflect.DeepEqual(expected, messages(results[1])) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, messages(results[1])))
+	}
+	expected = []interface{}{`Field "root" is not allowed.`}
+	if !reflect.DeepEqual(expected, messages(results[2])) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, messages(results[2])))
+	}
+}
+
+func TestHandler_WrapsTheResolversWithTheMiddlewares(t *testing.T) {
+	shout := func(next graphql.FieldResolveFn) graphql.FieldResolveFn {
+		return func(p graphql.GQLFRParams) interface{} {
+			if value, ok := next(p).(string); ok {
+				return strings.ToUpper(value)
+			}
+			return nil
+		}
+	}
+	h := handler.New(&handler.Config{
+		Schema:      newTestSchema(t),
+		Middlewares: []graphql.FieldMiddleware{shout},
+	})
+
+	r := httptest.NewRequest("GET", "/graphql?query="+url.QueryEscape(`{ greeting }`), nil)
+	_, _, body := serve(h, r)
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{"greeting": "HELLO WORLD"},
+	}
+	if !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, body))
+	}
+
+	r = httptest.NewRequest("POST", "/graphql", strings.NewReader(`[{"query": "{ greeting(name: \"Luke\") }"}]`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	var results []interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatalf("Unexpected response: %v", w.Body.String())
+	}
+	expectedResults := []interface{}{
+		map[string]interface{}{
+			"data": map[string]interface{}{"greeting": "HELLO LUKE"},
+		},
+	}
+	if !reflect.DeepEqual(expectedResults, results) {
+		t.Fatalf("Unexpected results, Diff: %v", testutil.Diff(expectedResults, results))
+	}
+}
+
+func TestHandler_ServesPersistedQueries(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema:           newTestSchema(t),
+		PersistedQueries: graphql.NewMemoryPersistedQueryStore(),
+	})
+	query := `mutation M { writeGreeting }`
+	extensions := `{"persistedQuery": {"version": 1, "sha256Hash": "` + graphql.HashQuery(query) + `"}}`
+
+	r := httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"extensions": `+extensions+`}`))
+	_, _, body := serve(h, r)
+	expected := map[string]interface{}{
+		"data": nil,
+		"errors": []interface{}{
+			map[string]interface{}{
+				"message":    "PersistedQueryNotFound",
+				"extensions": map[string]interface{}{"code": "PERSISTED_QUERY_NOT_FOUND"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, body))
+	}
+
+	r = httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query": "`+query+`", "extensions": `+extensions+`}`))
+	status, _, body := serve(h, r)
+	expected = map[string]interface{}{
+		"data": map[string]interface{}{"writeGreeting": "written"},
+	}
+	if status != http.StatusOK {
+		t.Fatalf("Unexpected status: %v", status)
+	}
+	if !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, body))
+	}
+
+	// the stored mutation is still refused over GET
+	r = httptest.NewRequest("GET", "/graphql?extensions="+url.QueryEscape(extensions), nil)
+	status, _, body = serve(h, r)
+	expected = errorBody("Can only perform a mutation operation from a POST request.")
+	if status != http.StatusMethodNotAllowed {
+		t.Fatalf("Unexpected status: %v", status)
+	}
+	if !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, body))
+	}
+}
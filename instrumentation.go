@@ -0,0 +1,109 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+/**
+ * Instrumentation observes the phases of the requests, see
+ * Params.Instrumentation.
+ *
+ * Each hook is called when its phase starts, and returns a function called
+ * with the outcome of the phase once it ends, which may be nil. The context
+ * returned by InstrumentRequest is the context of the rest of the request, it
+ * is passed to the other hooks and to the resolvers, so that the hooks may
+ * keep the state of each request in it.
+ *
+ * Field hooks are called concurrently when fields are resolved concurrently.
+ */
+type Instrumentation interface {
+	// InstrumentRequest is called when Graphql starts, and its function once
+	// the result of Graphql is complete. Subscribe calls the other hooks only.
+	InstrumentRequest(ctx context.Context, p Params) (context.Context, func(result *Result))
+	// InstrumentParse is called when the request string is parsed, it is not
+	// called for the documents found in the document cache.
+	InstrumentParse(ctx context.Context, requestString string) func(errs []gqlerrors.FormattedError)
+	// InstrumentValidate is called when the document is validated, it is not
+	// called for the documents found in the document cache.
+	InstrumentValidate(ctx context.Context, document *ast.Document) func(errs []gqlerrors.FormattedError)
+	// InstrumentExecute is called when the operation is executed.
+	InstrumentExecute(ctx context.Context, p ExecuteParams) func(result *Result)
+	// InstrumentField is called when the resolve function of a field is
+	// called, and its function once the field is resolved, after the deferred
+	// value of the field is awaited if any.
+	InstrumentField(ctx context.Context, info ResolveInfo) func(result interface{}, err error)
+}
+
+/**
+ * NoopInstrumentation implements Instrumentation without observing anything,
+ * instrumentations may embed it to implement only some of the hooks.
+ */
+type NoopInstrumentation struct{}
+
+func (NoopInstrumentation) InstrumentRequest(ctx context.Context, p Params) (context.Context, func(result *Result)) {
+	return ctx, nil
+}
+
+func (NoopInstrumentation) InstrumentParse(ctx context.Context, requestString string) func(errs []gqlerrors.FormattedError) {
+	return nil
+}
+
+func (NoopInstrumentation) InstrumentValidate(ctx context.Context, document *ast.Document) func(errs []gqlerrors.FormattedError) {
+	return nil
+}
+
+func (NoopInstrumentation) InstrumentExecute(ctx context.Context, p ExecuteParams) func(result *Result) {
+	return nil
+}
+
+func (NoopInstrumentation) InstrumentField(ctx context.Context, info ResolveInfo) func(result interface{}, err error) {
+	return nil
+}
+
+// instrumentFields returns the middleware calling the field hooks of the
+// instrumentation around every resolve function.
+func instrumentFields(instrumentation Instrumentation) FieldMiddleware {
+	return func(next FieldResolveFn) FieldResolveFn {
+		return func(p GQLFRParams) (result interface{}) {
+			finish := instrumentation.InstrumentField(p.Context, p.Info)
+			if finish == nil {
+				return next(p)
+			}
+			defer func() {
+				if r := recover(); r != nil {
+					finish(nil, panicError(r))
+					panic(r)
+				}
+			}()
+			result = next(p)
+			thunk, ok := asThunk(result)
+			if !ok {
+				finish(result, nil)
+				return result
+			}
+			return ThunkFn(func() (value interface{}, err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						finish(nil, panicError(r))
+						panic(r)
+					}
+				}()
+				value, err = thunk.Value()
+				finish(value, err)
+				return value, err
+			})
+		}
+	}
+}
+
+// panicError returns the error a recovered panic value stands for.
+func panicError(r interface{}) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
@@ -0,0 +1,227 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type requestIDKey struct{}
+
+// recordingInstrumentation records the events of every hook.
+type recordingInstrumentation struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recordingInstrumentation) record(format string, a ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf(format, a...))
+}
+
+func (r *recordingInstrumentation) InstrumentRequest(ctx context.Context, p graphql.Params) (context.Context, func(result *graphql.Result)) {
+	r.record("request start %v", p.OperationName)
+	return context.WithValue(ctx, requestIDKey{}, "request-1"), func(result *graphql.Result) {
+		r.record("request end %v errors", len(result.Errors))
+	}
+}
+
+func (r *recordingInstrumentation) InstrumentParse(ctx context.Context, requestString string) func(errs []gqlerrors.FormattedError) {
+	r.record("parse start %v", ctx.Value(requestIDKey{}))
+	return func(errs []gqlerrors.FormattedError) {
+		r.record("parse end %v errors", len(errs))
+	}
+}
+
+func (r *recordingInstrumentation) InstrumentValidate(ctx context.Context, document *ast.Document) func(errs []gqlerrors.FormattedError) {
+	r.record("validate start %v definitions", len(document.Definitions))
+	return func(errs []gqlerrors.FormattedError) {
+		r.record("validate end %v errors", len(errs))
+	}
+}
+
+func (r *recordingInstrumentation) InstrumentExecute(ctx context.Context, p graphql.ExecuteParams) func(result *graphql.Result) {
+	r.record("execute start %v", ctx.Value(requestIDKey{}))
+	return func(result *graphql.Result) {
+		r.record("execute end %v errors", len(result.Errors))
+	}
+}
+
+func (r *recordingInstrumentation) InstrumentField(ctx context.Context, info graphql.ResolveInfo) func(result interface{}, err error) {
+	r.record("field start %v %v.%v: %v", info.Path.AsArray(), info.ParentType.GetName(), info.FieldName, info.ReturnType)
+	return func(result interface{}, err error) {
+		r.record("field end %v %v %v", info.Path.AsArray(), result, err)
+	}
+}
+
+func newInstrumentationTestSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"requestID": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return p.Context.Value(requestIDKey{})
+					},
+				},
+				"deferred": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return func() (interface{}, error) {
+							return "deferred value", nil
+						}
+					},
+				},
+				"broken": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						panic(errors.New("broken"))
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return schema
+}
+
+func TestInstrumentation_ObservesEveryPhaseOfTheRequest(t *testing.T) {
+	instrumentation := &recordingInstrumentation{}
+	result := graphql.Graphql(graphql.Params{
+		Schema:          newInstrumentationTestSchema(t),
+		RequestString:   `query Q { requestID deferred broken }`,
+		OperationName:   "Q",
+		Instrumentation: instrumentation,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "broken" {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+
+	expected := []string{
+		"request start Q",
+		"parse start request-1",
+		"parse end 0 errors",
+		"validate start 1 definitions",
+		"validate end 0 errors",
+		"execute start request-1",
+		"field start [requestID] Query.requestID: String",
+		"field end [requestID] request-1 <nil>",
+		"field start [deferred] Query.deferred: String",
+		"field start [broken] Query.broken: String",
+		"field end [broken] <nil> broken",
+		"field end [deferred] deferred value <nil>",
+		"execute end 1 errors",
+		"request end 1 errors",
+	}
+	if !reflect.DeepEqual(expected, instrumentation.events) {
+		t.Fatalf("Unexpected events, Diff: %v", testutil.Diff(expected, instrumentation.events))
+	}
+
+	instrumentation = &recordingInstrumentation{}
+	graphql.Graphql(graphql.Params{
+		Schema:          newInstrumentationTestSchema(t),
+		RequestString:   `{ unknown }`,
+		Instrumentation: instrumentation,
+	})
+	expected = []string{
+		"request start ",
+		"parse start request-1",
+		"parse end 0 errors",
+		"validate start 1 definitions",
+		"validate end 1 errors",
+		"request end 1 errors",
+	}
+	if !reflect.DeepEqual(expected, instrumentation.events) {
+		t.Fatalf("Unexpected events, Diff: %v", testutil.Diff(expected, instrumentation.events))
+	}
+}
+
+func TestTracing_AddsTheTimingsOfTheRequestToItsExtensions(t *testing.T) {
+	result := graphql.Graphql(graphql.Params{
+		Schema:          testutil.StarWarsSchema,
+		RequestString:   `{ hero { name friends { name } } }`,
+		Instrumentation: graphql.Tracing{},
+	})
+	if result.HasErrors() {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	data, ok := result.Extensions[graphql.TracingExtensionKey].(*graphql.TracingData)
+	if !ok {
+		t.Fatalf("Unexpected extensions: %v", result.Extensions)
+	}
+	if data.Version != 1 || data.Duration <= 0 || !data.EndTime.After(data.StartTime) {
+		t.Fatalf("Unexpected tracing data: %+v", data)
+	}
+	if data.Parsing.Duration <= 0 || data.Validation.StartOffset < data.Parsing.StartOffset+data.Parsing.Duration {
+		t.Fatalf("Unexpected parsing and validation: %+v %+v", data.Parsing, data.Validation)
+	}
+
+	resolvers := []string{}
+	for _, resolver := range data.Execution.Resolvers {
+		if resolver.StartOffset < data.Validation.StartOffset || resolver.Duration < 0 ||
+			resolver.StartOffset+resolver.Duration > data.Duration {
+			t.Fatalf("Unexpected resolver timing: %+v", resolver)
+		}
+		resolvers = append(resolvers, fmt.Sprintf("%v %v.%v: %v",
+			resolver.Path, resolver.ParentType, resolver.FieldName, resolver.ReturnType))
+	}
+	expected := []string{
+		"[hero] Query.hero: Character",
+		"[hero name] Droid.name: String",
+		"[hero friends] Droid.friends: [Character]",
+		"[hero friends 0 name] Human.name: String",
+		"[hero friends 1 name] Human.name: String",
+		"[hero friends 2 name] Human.name: String",
+	}
+	if !reflect.DeepEqual(expected, resolvers) {
+		t.Fatalf("Unexpected resolvers, Diff: %v", testutil.Diff(expected, resolvers))
+	}
+
+	// the tracing data follows the Apollo tracing format
+	var extensions map[string]map[string]interface{}
+	b, err := json.Marshal(result.Extensions)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(b, &extensions); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	keys := []string{}
+	for _, key := range []string{"version", "startTime", "endTime", "duration", "parsing", "validation", "execution"} {
+		if _, ok := extensions["tracing"][key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) != 7 {
+		t.Fatalf("Unexpected tracing JSON: %s", b)
+	}
+}
+
+func TestTracing_IgnoresExecutionsOutOfATracedRequest(t *testing.T) {
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema:          testutil.StarWarsSchema,
+		AST:             testutil.TestParse(t, `{ hero { name } }`),
+		Instrumentation: graphql.Tracing{},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{"name": "R2-D2"},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
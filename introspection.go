@@ -3,6 +3,7 @@ package graphql
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/printer"
@@ -27,6 +28,7 @@ var __InputValue *Object
 var __EnumValue *Object
 
 var __TypeKind *Enum
+var __DirectiveLocation *Enum
 
 var SchemaMetaFieldDef *FieldDefinition
 var TypeMetaFieldDef *FieldDefinition
@@ -142,19 +144,34 @@ func init() {
 						if inputVal.DefaultValue == nil {
 							return nil
 						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
+						astVal := astFromValue(inputVal.DefaultValue, inputVal.Type)
 						return printer.Print(astVal)
 					}
 					if inputVal, ok := p.Source.(*InputObjectField); ok {
 						if inputVal.DefaultValue == nil {
 							return nil
 						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
+						astVal := astFromValue(inputVal.DefaultValue, inputVal.Type)
 						return printer.Print(astVal)
 					}
 					return nil
 				},
 			},
+			"isDeprecated": &FieldConfig{
+				Type: NewNonNull(Boolean),
+				Resolve: func(p GQLFRParams) interface{} {
+					switch inputVal := p.Source.(type) {
+					case *Argument:
+						return (inputVal.DeprecationReason != "")
+					case *InputObjectField:
+						return (inputVal.DeprecationReason != "")
+					}
+					return false
+				},
+			},
+			"deprecationReason": &FieldConfig{
+				Type: String,
+			},
 		},
 	})
 
@@ -169,9 +186,16 @@ func init() {
 			},
 			"args": &FieldConfig{
 				Type: NewNonNull(NewList(NewNonNull(__InputValue))),
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
+				},
 				Resolve: func(p GQLFRParams) interface{} {
 					if field, ok := p.Source.(*FieldDefinition); ok {
-						return field.Args
+						includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
+						return filterDeprecatedArgs(field.Args, includeDeprecated)
 					}
 					return []interface{}{}
 				},
@@ -194,6 +218,90 @@ func init() {
 		},
 	})
 
+	__DirectiveLocation = NewEnum(EnumConfig{
+		Name: "__DirectiveLocation",
+		Description: "A Directive can be adjacent to many parts of the GraphQL language, a " +
+			"__DirectiveLocation describes one such possible adjacencies.",
+		Values: EnumValueConfigMap{
+			"QUERY": &EnumValueConfig{
+				Value:       DirectiveLocationQuery,
+				Description: "Location adjacent to a query operation.",
+			},
+			"MUTATION": &EnumValueConfig{
+				Value:       DirectiveLocationMutation,
+				Description: "Location adjacent to a mutation operation.",
+			},
+			"SUBSCRIPTION": &EnumValueConfig{
+				Value:       DirectiveLocationSubscription,
+				Description: "Location adjacent to a subscription operation.",
+			},
+			"FIELD": &EnumValueConfig{
+				Value:       DirectiveLocationField,
+				Description: "Location adjacent to a field.",
+			},
+			"FRAGMENT_DEFINITION": &EnumValueConfig{
+				Value:       DirectiveLocationFragmentDefinition,
+				Description: "Location adjacent to a fragment definition.",
+			},
+			"FRAGMENT_SPREAD": &EnumValueConfig{
+				Value:       DirectiveLocationFragmentSpread,
+				Description: "Location adjacent to a fragment spread.",
+			},
+			"INLINE_FRAGMENT": &EnumValueConfig{
+				Value:       DirectiveLocationInlineFragment,
+				Description: "Location adjacent to an inline fragment.",
+			},
+			"VARIABLE_DEFINITION": &EnumValueConfig{
+				Value:       DirectiveLocationVariableDefinition,
+				Description: "Location adjacent to a variable definition.",
+			},
+			"SCHEMA": &EnumValueConfig{
+				Value:       DirectiveLocationSchema,
+				Description: "Location adjacent to a schema definition.",
+			},
+			"SCALAR": &EnumValueConfig{
+				Value:       DirectiveLocationScalar,
+				Description: "Location adjacent to a scalar definition.",
+			},
+			"OBJECT": &EnumValueConfig{
+				Value:       DirectiveLocationObject,
+				Description: "Location adjacent to an object type definition.",
+			},
+			"FIELD_DEFINITION": &EnumValueConfig{
+				Value:       DirectiveLocationFieldDefinition,
+				Description: "Location adjacent to a field definition.",
+			},
+			"ARGUMENT_DEFINITION": &EnumValueConfig{
+				Value:       DirectiveLocationArgumentDefinition,
+				Description: "Location adjacent to an argument definition.",
+			},
+			"INTERFACE": &EnumValueConfig{
+				Value:       DirectiveLocationInterface,
+				Description: "Location adjacent to an interface definition.",
+			},
+			"UNION": &EnumValueConfig{
+				Value:       DirectiveLocationUnion,
+				Description: "Location adjacent to a union definition.",
+			},
+			"ENUM": &EnumValueConfig{
+				Value:       DirectiveLocationEnum,
+				Description: "Location adjacent to an enum definition.",
+			},
+			"ENUM_VALUE": &EnumValueConfig{
+				Value:       DirectiveLocationEnumValue,
+				Description: "Location adjacent to an enum value definition.",
+			},
+			"INPUT_OBJECT": &EnumValueConfig{
+				Value:       DirectiveLocationInputObject,
+				Description: "Location adjacent to an input object type definition.",
+			},
+			"INPUT_FIELD_DEFINITION": &EnumValueConfig{
+				Value:       DirectiveLocationInputFieldDefinition,
+				Description: "Location adjacent to an input object field definition.",
+			},
+		},
+	})
+
 	__Directive = NewObject(ObjectConfig{
 		Name: "__Directive",
 		Fields: FieldConfigMap{
@@ -203,19 +311,57 @@ func init() {
 			"description": &FieldConfig{
 				Type: String,
 			},
+			"locations": &FieldConfig{
+				Type: NewNonNull(NewList(
+					NewNonNull(__DirectiveLocation),
+				)),
+				Resolve: func(p GQLFRParams) interface{} {
+					if directive, ok := p.Source.(*Directive); ok {
+						return directive.GetLocations()
+					}
+					return nil
+				},
+			},
 			"args": &FieldConfig{
 				Type: NewNonNull(NewList(
 					NewNonNull(__InputValue),
 				)),
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
+				},
+				Resolve: func(p GQLFRParams) interface{} {
+					if directive, ok := p.Source.(*Directive); ok {
+						includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
+						return filterDeprecatedArgs(directive.Args, includeDeprecated)
+					}
+					return []interface{}{}
+				},
 			},
 			"onOperation": &FieldConfig{
-				Type: NewNonNull(Boolean),
+				Type:              NewNonNull(Boolean),
+				DeprecationReason: "Use `locations`.",
+				Resolve: resolveDirectiveOnFn(
+					DirectiveLocationQuery,
+					DirectiveLocationMutation,
+					DirectiveLocationSubscription,
+				),
 			},
 			"onFragment": &FieldConfig{
-				Type: NewNonNull(Boolean),
+				Type:              NewNonNull(Boolean),
+				DeprecationReason: "Use `locations`.",
+				Resolve: resolveDirectiveOnFn(
+					DirectiveLocationFragmentDefinition,
+					DirectiveLocationFragmentSpread,
+					DirectiveLocationInlineFragment,
+				),
 			},
 			"onField": &FieldConfig{
-				Type: NewNonNull(Boolean),
+				Type:              NewNonNull(Boolean),
+				DeprecationReason: "Use `locations`.",
+				Resolve:           resolveDirectiveOnFn(DirectiveLocationField),
 			},
 		},
 	})
@@ -397,11 +543,21 @@ mutation operations.`,
 	})
 	__Type.AddFieldConfig("inputFields", &FieldConfig{
 		Type: NewList(NewNonNull(__InputValue)),
+		Args: FieldConfigArgument{
+			"includeDeprecated": &ArgumentConfig{
+				Type:         Boolean,
+				DefaultValue: false,
+			},
+		},
 		Resolve: func(p GQLFRParams) interface{} {
+			includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
 			switch ttype := p.Source.(type) {
 			case *InputObject:
 				fields := []*InputObjectField{}
 				for _, field := range ttype.GetFields() {
+					if !includeDeprecated && field.DeprecationReason != "" {
+						continue
+					}
 					fields = append(fields, field)
 				}
 				return fields
@@ -458,6 +614,38 @@ mutation operations.`,
 
 }
 
+// filterDeprecatedArgs returns the arguments, without the deprecated ones
+// unless includeDeprecated is true.
+func filterDeprecatedArgs(args []*Argument, includeDeprecated bool) []*Argument {
+	if includeDeprecated {
+		return args
+	}
+	filtered := []*Argument{}
+	for _, arg := range args {
+		if arg.DeprecationReason == "" {
+			filtered = append(filtered, arg)
+		}
+	}
+	return filtered
+}
+
+// resolveDirectiveOnFn resolves a legacy location field of __Directive, which
+// is true when the directive allows any of the locations.
+func resolveDirectiveOnFn(locations ...DirectiveLocation) FieldResolveFn {
+	return func(p GQLFRParams) interface{} {
+		directive, ok := p.Source.(*Directive)
+		if !ok {
+			return false
+		}
+		for _, location := range locations {
+			if directive.AllowsLocation(location) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 /**
  * Produces a GraphQL Value AST given a Golang value.
  *
@@ -520,8 +708,44 @@ func astFromValue(value interface{}, ttype Type) ast.Value {
 		}
 	}
 
-	if valueVal.Type().Kind() == reflect.Map {
-		// TODO: implement astFromValue from Map to Value
+	// Populate the fields of the input object by creating ASTs from each value
+	// in the map according to the fields in the input type.
+	if valueVal.Type().Kind() == reflect.Map && valueVal.Type().Key().Kind() == reflect.String {
+		fieldTypes := InputObjectFieldMap{}
+		if ttype, ok := ttype.(*InputObject); ok {
+			fieldTypes = ttype.GetFields()
+		}
+		fieldNames := []string{}
+		for _, key := range valueVal.MapKeys() {
+			fieldNames = append(fieldNames, key.String())
+		}
+		sort.Strings(fieldNames)
+		fields := []*ast.ObjectField{}
+		for _, fieldName := range fieldNames {
+			var fieldType Type
+			if field, ok := fieldTypes[fieldName]; ok {
+				fieldType = field.Type
+			}
+			fieldValue := astFromValue(valueVal.MapIndex(reflect.ValueOf(fieldName).Convert(valueVal.Type().Key())).Interface(), fieldType)
+			if fieldValue != nil {
+				fields = append(fields, ast.NewObjectField(&ast.ObjectField{
+					Name:  ast.NewName(&ast.Name{Value: fieldName}),
+					Value: fieldValue,
+				}))
+			}
+		}
+		return ast.NewObjectValue(&ast.ObjectValue{
+			Fields: fields,
+		})
+	}
+
+	// Enum values are printed with their names, whatever their internal value.
+	if ttype, ok := ttype.(*Enum); ok {
+		if name, ok := ttype.Serialize(value).(string); ok {
+			return ast.NewEnumValue(&ast.EnumValue{
+				Value: name,
+			})
+		}
 	}
 
 	if value, ok := value.(bool); ok {
@@ -11,7 +11,7 @@ import (
 )
 
 func g(t *testing.T, p graphql.Params) *graphql.Result {
-	return graphql.Graphql(p)
+	return testutil.UnorderedResult(graphql.Graphql(p))
 }
 
 func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
@@ -260,7 +260,17 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 						},
 						map[string]interface{}{
 							"name": "inputFields",
-							"args": []interface{}{},
+							"args": []interface{}{
+								map[string]interface{}{
+									"name": "includeDeprecated",
+									"type": map[string]interface{}{
+										"kind":   "SCALAR",
+										"name":   "Boolean",
+										"ofType": nil,
+									},
+									"defaultValue": "false",
+								},
+							},
 							"type": map[string]interface{}{
 								"kind": "LIST",
 								"name": nil,
@@ -294,6 +304,111 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 					"enumValues":    nil,
 					"possibleTypes": nil,
 				},
+				map[string]interface{}{
+					"kind":        "ENUM",
+					"name":        "__DirectiveLocation",
+					"fields":      nil,
+					"inputFields": nil,
+					"interfaces":  nil,
+					"enumValues": []interface{}{
+						map[string]interface{}{
+							"name":              "QUERY",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "MUTATION",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "SUBSCRIPTION",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "FIELD",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "FRAGMENT_DEFINITION",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "FRAGMENT_SPREAD",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "INLINE_FRAGMENT",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "VARIABLE_DEFINITION",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "SCHEMA",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "SCALAR",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "OBJECT",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "FIELD_DEFINITION",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "ARGUMENT_DEFINITION",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "INTERFACE",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "UNION",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "ENUM",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "ENUM_VALUE",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "INPUT_OBJECT",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name":              "INPUT_FIELD_DEFINITION",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+					},
+					"possibleTypes": nil,
+				},
 				map[string]interface{}{
 					"kind":        "ENUM",
 					"name":        "__TypeKind",
@@ -394,7 +509,17 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 						},
 						map[string]interface{}{
 							"name": "args",
-							"args": []interface{}{},
+							"args": []interface{}{
+								map[string]interface{}{
+									"name": "includeDeprecated",
+									"type": map[string]interface{}{
+										"kind":   "SCALAR",
+										"name":   "Boolean",
+										"ofType": nil,
+									},
+									"defaultValue": "false",
+								},
+							},
 							"type": map[string]interface{}{
 								"kind": "NON_NULL",
 								"name": nil,
@@ -517,6 +642,32 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 							"isDeprecated":      false,
 							"deprecationReason": nil,
 						},
+						map[string]interface{}{
+							"name": "isDeprecated",
+							"args": []interface{}{},
+							"type": map[string]interface{}{
+								"kind": "NON_NULL",
+								"name": nil,
+								"ofType": map[string]interface{}{
+									"kind":   "SCALAR",
+									"name":   "Boolean",
+									"ofType": nil,
+								},
+							},
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]interface{}{
+							"name": "deprecationReason",
+							"args": []interface{}{},
+							"type": map[string]interface{}{
+								"kind":   "SCALAR",
+								"name":   "String",
+								"ofType": nil,
+							},
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
 					},
 					"inputFields":   nil,
 					"interfaces":    []interface{}{},
@@ -617,7 +768,17 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 						},
 						map[string]interface{}{
 							"name": "args",
-							"args": []interface{}{},
+							"args": []interface{}{
+								map[string]interface{}{
+									"name": "includeDeprecated",
+									"type": map[string]interface{}{
+										"kind":   "SCALAR",
+										"name":   "Boolean",
+										"ofType": nil,
+									},
+									"defaultValue": "false",
+								},
+							},
 							"type": map[string]interface{}{
 								"kind": "NON_NULL",
 								"name": nil,
@@ -638,45 +799,22 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 							"deprecationReason": nil,
 						},
 						map[string]interface{}{
-							"name": "onOperation",
+							"name": "locations",
 							"args": []interface{}{},
 							"type": map[string]interface{}{
 								"kind": "NON_NULL",
 								"name": nil,
 								"ofType": map[string]interface{}{
-									"kind":   "SCALAR",
-									"name":   "Boolean",
-									"ofType": nil,
-								},
-							},
-							"isDeprecated":      false,
-							"deprecationReason": nil,
-						},
-						map[string]interface{}{
-							"name": "onFragment",
-							"args": []interface{}{},
-							"type": map[string]interface{}{
-								"kind": "NON_NULL",
-								"name": nil,
-								"ofType": map[string]interface{}{
-									"kind":   "SCALAR",
-									"name":   "Boolean",
-									"ofType": nil,
-								},
-							},
-							"isDeprecated":      false,
-							"deprecationReason": nil,
-						},
-						map[string]interface{}{
-							"name": "onField",
-							"args": []interface{}{},
-							"type": map[string]interface{}{
-								"kind": "NON_NULL",
-								"name": nil,
-								"ofType": map[string]interface{}{
-									"kind":   "SCALAR",
-									"name":   "Boolean",
-									"ofType": nil,
+									"kind": "LIST",
+									"name": nil,
+									"ofType": map[string]interface{}{
+										"kind": "NON_NULL",
+										"name": nil,
+										"ofType": map[string]interface{}{
+											"kind": "ENUM",
+											"name": "__DirectiveLocation",
+										},
+									},
 								},
 							},
 							"isDeprecated":      false,
@@ -707,9 +845,11 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 							},
 						},
 					},
-					"onOperation": false,
-					"onFragment":  true,
-					"onField":     true,
+					"locations": []interface{}{
+						"FIELD",
+						"FRAGMENT_SPREAD",
+						"INLINE_FRAGMENT",
+					},
 				},
 				map[string]interface{}{
 					"name": "skip",
@@ -728,9 +868,31 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 							},
 						},
 					},
-					"onOperation": false,
-					"onFragment":  true,
-					"onField":     true,
+					"locations": []interface{}{
+						"FIELD",
+						"FRAGMENT_SPREAD",
+						"INLINE_FRAGMENT",
+					},
+				},
+				map[string]interface{}{
+					"name": "deprecated",
+					"args": []interface{}{
+						map[string]interface{}{
+							"defaultValue": `"No longer supported"`,
+							"name":         "reason",
+							"type": map[string]interface{}{
+								"kind":   "SCALAR",
+								"name":   "String",
+								"ofType": nil,
+							},
+						},
+					},
+					"locations": []interface{}{
+						"FIELD_DEFINITION",
+						"ARGUMENT_DEFINITION",
+						"INPUT_FIELD_DEFINITION",
+						"ENUM_VALUE",
+					},
 				},
 			},
 		},
@@ -1022,6 +1184,131 @@ func TestIntrospection_RespectsTheIncludeDeprecatedParameterForFields(t *testing
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+func TestIntrospection_RespectsTheIncludeDeprecatedParameterForArgsAndInputFields(t *testing.T) {
+
+	testInputObject := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TestInputObject",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"nonDeprecated": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"deprecated": &graphql.InputObjectFieldConfig{
+				Type:              graphql.String,
+				DeprecationReason: "Removed in 1.0",
+			},
+		},
+	})
+	testType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TestType",
+		Fields: graphql.FieldConfigMap{
+			"field": &graphql.FieldConfig{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"deprecated": &graphql.ArgumentConfig{
+						Type:              graphql.String,
+						DeprecationReason: "Removed in 1.0",
+					},
+					"input": &graphql.ArgumentConfig{
+						Type: testInputObject,
+					},
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: testType,
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        testType: __type(name: "TestType") {
+          fields {
+            trueArgs: args(includeDeprecated: true) {
+              name
+              isDeprecated
+              deprecationReason
+            }
+            omittedArgs: args {
+              name
+            }
+          }
+        }
+        testInputObject: __type(name: "TestInputObject") {
+          trueInputFields: inputFields(includeDeprecated: true) {
+            name
+            isDeprecated
+            deprecationReason
+          }
+          omittedInputFields: inputFields {
+            name
+          }
+        }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"testType": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{
+						"trueArgs": []interface{}{
+							map[string]interface{}{
+								"name":              "deprecated",
+								"isDeprecated":      true,
+								"deprecationReason": "Removed in 1.0",
+							},
+							map[string]interface{}{
+								"name":              "input",
+								"isDeprecated":      false,
+								"deprecationReason": nil,
+							},
+						},
+						"omittedArgs": []interface{}{
+							map[string]interface{}{
+								"name": "input",
+							},
+						},
+					},
+				},
+			},
+			"testInputObject": map[string]interface{}{
+				"trueInputFields": []interface{}{
+					map[string]interface{}{
+						"name":              "nonDeprecated",
+						"isDeprecated":      false,
+						"deprecationReason": nil,
+					},
+					map[string]interface{}{
+						"name":              "deprecated",
+						"isDeprecated":      true,
+						"deprecationReason": "Removed in 1.0",
+					},
+				},
+				"omittedInputFields": []interface{}{
+					map[string]interface{}{
+						"name": "nonDeprecated",
+					},
+				},
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	// the deprecated ones are omitted by default
+	testTypeData := result.Data.(map[string]interface{})["testType"].(map[string]interface{})
+	omittedArgs := testTypeData["fields"].([]interface{})[0].(map[string]interface{})["omittedArgs"].([]interface{})
+	testInputObjectData := result.Data.(map[string]interface{})["testInputObject"].(map[string]interface{})
+	omittedInputFields := testInputObjectData["omittedInputFields"].([]interface{})
+	if len(omittedArgs) != 1 || len(omittedInputFields) != 1 {
+		t.Fatalf("Unexpected deprecated args or input fields: %v, %v", omittedArgs, omittedInputFields)
+	}
+}
 func TestIntrospection_IdentifiesDeprecatedEnumValues(t *testing.T) {
 
 	testEnum := graphql.NewEnum(graphql.EnumConfig{
@@ -61,11 +61,12 @@ func (def *ObjectDefinition) GetOperation() string {
 
 // FieldDefinition implements Node
 type FieldDefinition struct {
-	Kind      string
-	Loc       *Location
-	Name      *Name
-	Arguments []*InputValueDefinition
-	Type      Type
+	Kind       string
+	Loc        *Location
+	Name       *Name
+	Arguments  []*InputValueDefinition
+	Type       Type
+	Directives []*Directive
 }
 
 func NewFieldDefinition(def *FieldDefinition) *FieldDefinition {
@@ -73,11 +74,12 @@ func NewFieldDefinition(def *FieldDefinition) *FieldDefinition {
 		def = &FieldDefinition{}
 	}
 	return &FieldDefinition{
-		Kind:      kinds.FieldDefinition,
-		Loc:       def.Loc,
-		Name:      def.Name,
-		Arguments: def.Arguments,
-		Type:      def.Type,
+		Kind:       kinds.FieldDefinition,
+		Loc:        def.Loc,
+		Name:       def.Name,
+		Arguments:  def.Arguments,
+		Type:       def.Type,
+		Directives: def.Directives,
 	}
 }
 
@@ -96,6 +98,7 @@ type InputValueDefinition struct {
 	Name         *Name
 	Type         Type
 	DefaultValue Value
+	Directives   []*Directive
 }
 
 func NewInputValueDefinition(def *InputValueDefinition) *InputValueDefinition {
@@ -108,6 +111,7 @@ func NewInputValueDefinition(def *InputValueDefinition) *InputValueDefinition {
 		Name:         def.Name,
 		Type:         def.Type,
 		DefaultValue: def.DefaultValue,
+		Directives:   def.Directives,
 	}
 }
 
@@ -295,9 +299,10 @@ func (def *EnumDefinition) GetOperation() string {
 
 // EnumValueDefinition implements Node, Definition
 type EnumValueDefinition struct {
-	Kind string
-	Loc  *Location
-	Name *Name
+	Kind       string
+	Loc        *Location
+	Name       *Name
+	Directives []*Directive
 }
 
 func NewEnumValueDefinition(def *EnumValueDefinition) *EnumValueDefinition {
@@ -305,9 +310,10 @@ func NewEnumValueDefinition(def *EnumValueDefinition) *EnumValueDefinition {
 		def = &EnumValueDefinition{}
 	}
 	return &EnumValueDefinition{
-		Kind: kinds.EnumValueDefinition,
-		Loc:  def.Loc,
-		Name: def.Name,
+		Kind:       kinds.EnumValueDefinition,
+		Loc:        def.Loc,
+		Name:       def.Name,
+		Directives: def.Directives,
 	}
 }
 
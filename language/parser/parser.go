@@ -796,11 +796,16 @@ func parseFieldDefinition(parser *Parser) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewFieldDefinition(&ast.FieldDefinition{
-		Name:      name,
-		Arguments: args,
-		Type:      ttype,
-		Loc:       loc(parser, start),
+		Name:       name,
+		Arguments:  args,
+		Type:       ttype,
+		Directives: directives,
+		Loc:        loc(parser, start),
 	}), nil
 }
 
@@ -846,10 +851,15 @@ func parseInputValueDef(parser *Parser) (interface{}, error) {
 			defaultValue = val
 		}
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewInputValueDefinition(&ast.InputValueDefinition{
 		Name:         name,
 		Type:         ttype,
 		DefaultValue: defaultValue,
+		Directives:   directives,
 		Loc:          loc(parser, start),
 	}), nil
 }
@@ -971,9 +981,14 @@ func parseEnumValueDefinition(parser *Parser) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
-		Name: name,
-		Loc:  loc(parser, start),
+		Name:       name,
+		Directives: directives,
+		Loc:        loc(parser, start),
 	}), nil
 }
 
@@ -61,6 +61,7 @@ type Hello {
 								Loc:   testLoc(23, 29),
 							}),
 						}),
+						Directives: []*ast.Directive{},
 					}),
 				},
 			}),
@@ -105,6 +106,7 @@ extend type Hello {
 									Loc:   testLoc(30, 36),
 								}),
 							}),
+							Directives: []*ast.Directive{},
 						}),
 					},
 				}),
@@ -152,6 +154,7 @@ type Hello {
 								}),
 							}),
 						}),
+						Directives: []*ast.Directive{},
 					}),
 				},
 			}),
@@ -247,7 +250,8 @@ func TestSchemaParser_SingleValueEnum(t *testing.T) {
 							Value: "WORLD",
 							Loc:   testLoc(13, 18),
 						}),
-						Loc: testLoc(13, 18),
+						Loc:        testLoc(13, 18),
+						Directives: []*ast.Directive{},
 					}),
 				},
 			}),
@@ -276,14 +280,67 @@ func TestSchemaParser_DoubleValueEnum(t *testing.T) {
 							Value: "WO",
 							Loc:   testLoc(13, 15),
 						}),
-						Loc: testLoc(13, 15),
+						Loc:        testLoc(13, 15),
+						Directives: []*ast.Directive{},
 					}),
 					ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
 						Name: ast.NewName(&ast.Name{
 							Value: "RLD",
 							Loc:   testLoc(17, 20),
 						}),
-						Loc: testLoc(17, 20),
+						Loc:        testLoc(17, 20),
+						Directives: []*ast.Directive{},
+					}),
+				},
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
+func TestSchemaParser_EnumValueWithDirective(t *testing.T) {
+	body := `enum Hello { WORLD @deprecated(reason: "Gone") }`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 48),
+		Definitions: []ast.Node{
+			ast.NewEnumDefinition(&ast.EnumDefinition{
+				Loc: testLoc(0, 48),
+				Name: ast.NewName(&ast.Name{
+					Value: "Hello",
+					Loc:   testLoc(5, 10),
+				}),
+				Values: []*ast.EnumValueDefinition{
+					ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+						Name: ast.NewName(&ast.Name{
+							Value: "WORLD",
+							Loc:   testLoc(13, 18),
+						}),
+						Loc: testLoc(13, 46),
+						Directives: []*ast.Directive{
+							ast.NewDirective(&ast.Directive{
+								Name: ast.NewName(&ast.Name{
+									Value: "deprecated",
+									Loc:   testLoc(20, 30),
+								}),
+								Arguments: []*ast.Argument{
+									ast.NewArgument(&ast.Argument{
+										Name: ast.NewName(&ast.Name{
+											Value: "reason",
+											Loc:   testLoc(31, 37),
+										}),
+										Value: ast.NewStringValue(&ast.StringValue{
+											Value: "Gone",
+											Loc:   testLoc(39, 45),
+										}),
+										Loc: testLoc(31, 45),
+									}),
+								},
+								Loc: testLoc(19, 46),
+							}),
+						},
 					}),
 				},
 			}),
@@ -324,6 +381,7 @@ interface Hello {
 								Loc:   testLoc(28, 34),
 							}),
 						}),
+						Directives: []*ast.Directive{},
 					}),
 				},
 			}),
@@ -372,6 +430,7 @@ type Hello {
 									}),
 								}),
 								DefaultValue: nil,
+								Directives:   []*ast.Directive{},
 							}),
 						},
 						Type: ast.NewNamed(&ast.Named{
@@ -381,6 +440,7 @@ type Hello {
 								Loc:   testLoc(38, 44),
 							}),
 						}),
+						Directives: []*ast.Directive{},
 					}),
 				},
 			}),
@@ -432,6 +492,7 @@ type Hello {
 									Value: true,
 									Loc:   testLoc(38, 42),
 								}),
+								Directives: []*ast.Directive{},
 							}),
 						},
 						Type: ast.NewNamed(&ast.Named{
@@ -441,6 +502,7 @@ type Hello {
 								Loc:   testLoc(45, 51),
 							}),
 						}),
+						Directives: []*ast.Directive{},
 					}),
 				},
 			}),
@@ -492,6 +554,7 @@ type Hello {
 									}),
 								}),
 								DefaultValue: nil,
+								Directives:   []*ast.Directive{},
 							}),
 						},
 						Type: ast.NewNamed(&ast.Named{
@@ -501,6 +564,7 @@ type Hello {
 								Loc:   testLoc(41, 47),
 							}),
 						}),
+						Directives: []*ast.Directive{},
 					}),
 				},
 			}),
@@ -549,6 +613,7 @@ type Hello {
 									}),
 								}),
 								DefaultValue: nil,
+								Directives:   []*ast.Directive{},
 							}),
 							ast.NewInputValueDefinition(&ast.InputValueDefinition{
 								Loc: testLoc(39, 50),
@@ -564,6 +629,7 @@ type Hello {
 									}),
 								}),
 								DefaultValue: nil,
+								Directives:   []*ast.Directive{},
 							}),
 						},
 						Type: ast.NewNamed(&ast.Named{
@@ -573,6 +639,7 @@ type Hello {
 								Loc:   testLoc(53, 59),
 							}),
 						}),
+						Directives: []*ast.Directive{},
 					}),
 				},
 			}),
@@ -698,6 +765,7 @@ input Hello {
 							}),
 						}),
 						DefaultValue: nil,
+						Directives:   []*ast.Directive{},
 					}),
 				},
 			}),
@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -28,6 +29,38 @@ func getMapValue(m map[string]interface{}, key string) interface{} {
 	}
 	return valMap
 }
+
+// escapeString escapes the value of a string literal, so that it can be
+// printed within double quotes and parsed back to the same value.
+func escapeString(value string) string {
+	var buf bytes.Buffer
+	for _, r := range value {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	return buf.String()
+}
+
 func getMapValueString(m map[string]interface{}, key string) string {
 	tokens := strings.Split(key, ".")
 	valMap := m
@@ -252,7 +285,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 	"StringValue": func(p visitor.VisitFuncParams) (string, interface{}) {
 		switch node := p.Node.(type) {
 		case map[string]interface{}:
-			return visitor.ActionUpdate, `"` + getMapValueString(node, "Value") + `"`
+			return visitor.ActionUpdate, `"` + escapeString(getMapValueString(node, "Value")) + `"`
 		}
 		return visitor.ActionNoChange, nil
 	},
@@ -343,7 +376,8 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			name := getMapValueString(node, "Name")
 			ttype := getMapValueString(node, "Type")
 			args := toSliceString(getMapValue(node, "Arguments"))
-			str := name + wrap("(", join(args, ", "), ")") + ": " + ttype
+			directives := toSliceString(getMapValue(node, "Directives"))
+			str := name + wrap("(", join(args, ", "), ")") + ": " + ttype + wrap(" ", join(directives, " "), "")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -354,7 +388,8 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			name := getMapValueString(node, "Name")
 			ttype := getMapValueString(node, "Type")
 			defaultValue := getMapValueString(node, "DefaultValue")
-			str := name + ": " + ttype + wrap(" = ", defaultValue, "")
+			directives := toSliceString(getMapValue(node, "Directives"))
+			str := name + ": " + ttype + wrap(" = ", defaultValue, "") + wrap(" ", join(directives, " "), "")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -402,7 +437,9 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		switch node := p.Node.(type) {
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
-			return visitor.ActionUpdate, name
+			directives := toSliceString(getMapValue(node, "Directives"))
+			str := name + wrap(" ", join(directives, " "), "")
+			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
 	},
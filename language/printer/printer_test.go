@@ -105,3 +105,20 @@ fragment frag on Follower {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(results, expected))
 	}
 }
+
+func TestPrinter_EscapesStringValues(t *testing.T) {
+	astDoc := ast.NewStringValue(&ast.StringValue{
+		Value: "say \"hi\"\\\n\t\u0001",
+	})
+	results := printer.Print(astDoc)
+	expected := `"say \"hi\"\\\n\t\u0001"`
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+	// printed values parse back to the same value
+	parsed := parse(t, "{ field(arg: "+expected+") }")
+	printed := printer.Print(parsed)
+	if expected := "{\n  field(arg: " + expected + ")\n}\n"; !reflect.DeepEqual(printed, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
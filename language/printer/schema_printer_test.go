@@ -60,6 +60,7 @@ func TestSchemaPrinter_PrintsKitchenSink(t *testing.T) {
   four(argument: String = "string"): String
   five(argument: [String] = ["string", "string"]): String
   six(argument: InputType = {key: "value"}): Type
+  eight(argument: Int = 1 @deprecated): Type @deprecated(reason: "Use six.")
 }
 
 interface Bar {
@@ -74,11 +75,13 @@ scalar CustomScalar
 enum Site {
   DESKTOP
   MOBILE
+  TABLET @deprecated
 }
 
 input InputType {
   key: String!
   answer: Int = 42
+  question: String @deprecated(reason: "Use key.")
 }
 
 extend type Foo {
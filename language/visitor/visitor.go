@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+
+	"github.com/graphql-go/graphql/language/ast"
 )
 
 const (
@@ -90,11 +92,13 @@ var QueryDocumentKeys KeyMap = KeyMap{
 		"Name",
 		"Arguments",
 		"Type",
+		"Directives",
 	},
 	"InputValueDefinition": []string{
 		"Name",
 		"Type",
 		"DefaultValue",
+		"Directives",
 	},
 	"InterfaceDefinition": []string{
 		"Name",
@@ -109,7 +113,10 @@ var QueryDocumentKeys KeyMap = KeyMap{
 		"Name",
 		"Values",
 	},
-	"EnumValueDefinition": []string{"Name"},
+	"EnumValueDefinition": []string{
+		"Name",
+		"Directives",
+	},
 	"InputObjectDefinition": []string{
 		"Name",
 		"Fields",
@@ -154,11 +161,6 @@ type VisitorOptions struct {
 }
 
 func Visit(root interface{}, visitorOpts *VisitorOptions, keyMap KeyMap) interface{} {
-	visitorKeys := keyMap
-	if visitorKeys == nil {
-		visitorKeys = QueryDocumentKeys
-	}
-
 	var newRoot interface{}
 	// convert any interface{} into map[string]interface{}
 	b, err := json.Marshal(root)
@@ -169,6 +171,25 @@ func Visit(root interface{}, visitorOpts *VisitorOptions, keyMap KeyMap) interfa
 	if err != nil || newRoot == nil {
 		panic(fmt.Sprintf("Invalid root AST Node (2): %v", root))
 	}
+	return visit(newRoot, visitorOpts, keyMap)
+}
+
+// VisitAST walks the AST the same way Visit does, but without converting it
+// into maps first: visit functions receive the typed nodes (*ast.Field,
+// *ast.Name, ...) of the given tree.
+// Edits are applied to shallow copies, so the given AST is never mutated.
+func VisitAST(root ast.Node, visitorOpts *VisitorOptions, keyMap KeyMap) interface{} {
+	if isNilNode(root) {
+		panic(fmt.Sprintf("Invalid root AST Node: %v", root))
+	}
+	return visit(root, visitorOpts, keyMap)
+}
+
+func visit(newRoot interface{}, visitorOpts *VisitorOptions, keyMap KeyMap) interface{} {
+	visitorKeys := keyMap
+	if visitorKeys == nil {
+		visitorKeys = QueryDocumentKeys
+	}
 
 	var sstack *stack
 	var parent interface{}
@@ -197,6 +218,7 @@ Loop:
 			node = parent
 			parent, ancestors = pop(ancestors)
 			if isEdited {
+				node = cloneNode(node)
 				editOffset := 0
 				for _, edit := range edits {
 					arrayEditKey := 0
@@ -206,27 +228,39 @@ Loop:
 						arrayEditKey = edit.Key.(int)
 					}
 					if inArray && isNilNode(edit.Value) {
-						if n, ok := node.([]interface{}); ok {
+						switch n := node.(type) {
+						case []interface{}:
 							node = splice(n, arrayEditKey)
-						} else {
-							panic(fmt.Sprintf("Invalid AST Node (1): %v", node))
+						default:
+							if !isSlice(n) {
+								panic(fmt.Sprintf("Invalid AST Node (1): %v", node))
+							}
+							node = spliceSlice(n, arrayEditKey)
 						}
 						editOffset = editOffset + 1
 					} else {
 						if inArray {
-							if n, ok := node.([]interface{}); ok {
+							switch n := node.(type) {
+							case []interface{}:
 								n[arrayEditKey] = edit.Value
 								node = n
-							} else {
-								panic(fmt.Sprintf("Invalid AST Node (2): %v", node))
+							default:
+								if !isSlice(n) {
+									panic(fmt.Sprintf("Invalid AST Node (2): %v", node))
+								}
+								setValue(reflect.ValueOf(n).Index(arrayEditKey), edit.Value, node)
 							}
 						} else {
-							if n, ok := node.(map[string]interface{}); ok {
-								key := edit.Key.(string)
+							key := edit.Key.(string)
+							switch n := node.(type) {
+							case map[string]interface{}:
 								n[key] = edit.Value
 								node = n
-							} else {
-								panic(fmt.Sprintf("Invalid AST Node (3): %v", node))
+							default:
+								if !isNode(n) {
+									panic(fmt.Sprintf("Invalid AST Node (3): %v", node))
+								}
+								setValue(reflect.ValueOf(n).Elem().FieldByName(key), edit.Value, node)
 							}
 						}
 					}
@@ -272,15 +306,11 @@ Loop:
 			if !isNode(node) {
 				panic(fmt.Sprintf("Invalid AST Node (4): %v", node))
 			}
-			n, ok := node.(map[string]interface{})
+			kind, ok := getKind(node)
 			if !ok {
 				panic(fmt.Sprintf("Invalid AST Node (5): %v", node))
 			}
-			kind, ok := n["Kind"].(string)
-			if !ok {
-				kind = ""
-			}
-			visitFn := getVisitFn(visitorOpts, isLeaving, kind)
+			visitFn := GetVisitFn(visitorOpts, isLeaving, kind)
 			if visitFn != nil {
 				p := VisitFuncParams{
 					Node:      node,
@@ -345,28 +375,20 @@ Loop:
 			if !isNilNode(node) {
 				if inArray {
 					// get keys
-					if n, ok := node.([]interface{}); ok {
-						for _, m := range n {
-							keys = append(keys, m)
-						}
-					} else {
-						panic(fmt.Sprintf("Invalid AST Node (6): %v", node))
+					val := reflect.ValueOf(node)
+					for i := 0; i < val.Len(); i++ {
+						keys = append(keys, val.Index(i).Interface())
 					}
-
 				} else {
-					if n, ok := node.(map[string]interface{}); ok {
-						kind, ok := n["Kind"].(string)
-						if !ok {
-							kind = ""
-						}
-						if n, ok := visitorKeys[kind]; ok {
-							for _, m := range n {
-								keys = append(keys, m)
-							}
-						}
-					} else {
+					kind, ok := getKind(node)
+					if !ok {
 						panic(fmt.Sprintf("Invalid AST Node (7): %v", node))
 					}
+					if n, ok := visitorKeys[kind]; ok {
+						for _, m := range n {
+							keys = append(keys, m)
+						}
+					}
 				}
 			}
 
@@ -406,6 +428,73 @@ func splice(a []interface{}, i int) []interface{} {
 	return append(a[:i], a[i+1:]...)
 }
 
+// spliceSlice removes the i-th item of a typed slice, e.g. []*ast.Argument.
+func spliceSlice(a interface{}, i int) interface{} {
+	val := reflect.ValueOf(a)
+	if i < 0 || i >= val.Len() {
+		return a
+	}
+	return reflect.AppendSlice(val.Slice(0, i), val.Slice(i+1, val.Len())).Interface()
+}
+
+// cloneNode returns a shallow copy of a typed AST node or slice of nodes,
+// so that edits made while visiting do not leak into the original AST.
+// Maps and []interface{} produced by Visit are already copies and are
+// returned as is.
+func cloneNode(node interface{}) interface{} {
+	switch node.(type) {
+	case map[string]interface{}, []interface{}:
+		return node
+	}
+	val := reflect.ValueOf(node)
+	if !val.IsValid() {
+		return node
+	}
+	switch val.Type().Kind() {
+	case reflect.Slice:
+		clone := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		reflect.Copy(clone, val)
+		return clone.Interface()
+	case reflect.Ptr:
+		if val.IsNil() || val.Elem().Kind() != reflect.Struct {
+			return node
+		}
+		clone := reflect.New(val.Elem().Type())
+		clone.Elem().Set(val.Elem())
+		return clone.Interface()
+	}
+	return node
+}
+
+// setValue assigns an edited value to a field or slice item of a typed node.
+func setValue(dest reflect.Value, value interface{}, node interface{}) {
+	if !dest.IsValid() || !dest.CanSet() {
+		panic(fmt.Sprintf("Invalid AST Node (8): %v", node))
+	}
+	if isNilNode(value) {
+		dest.Set(reflect.Zero(dest.Type()))
+		return
+	}
+	val := reflect.ValueOf(value)
+	if !val.Type().AssignableTo(dest.Type()) {
+		panic(fmt.Sprintf("Invalid AST Node (9): %v", value))
+	}
+	dest.Set(val)
+}
+
+// getKind returns the kind of a node, which is either a typed ast.Node or
+// its map[string]interface{} representation.
+func getKind(node interface{}) (string, bool) {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		kind, _ := node["Kind"].(string)
+		return kind, true
+	case ast.Node:
+		return node.GetKind(), true
+	}
+	return "", false
+}
+
 func getField(obj interface{}, key interface{}) interface{} {
 	val := reflect.ValueOf(obj)
 	if val.Type().Kind() == reflect.Ptr {
@@ -459,6 +548,9 @@ func isNode(node interface{}) bool {
 	if !val.IsValid() {
 		return false
 	}
+	if _, ok := node.(ast.Node); ok {
+		return !isNilNode(node)
+	}
 	if val.Type().Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
@@ -489,7 +581,9 @@ func isNilNode(node interface{}) bool {
 	return val.Interface() == nil
 }
 
-func getVisitFn(visitorOpts *VisitorOptions, isLeaving bool, kind string) VisitFunc {
+// GetVisitFn returns the visit function to call for a node of the given kind,
+// when entering or leaving it.
+func GetVisitFn(visitorOpts *VisitorOptions, isLeaving bool, kind string) VisitFunc {
 	if visitorOpts == nil {
 		return nil
 	}
@@ -207,6 +207,86 @@ func TestVisitor_AllowsSkippingASubTree(t *testing.T) {
 	}
 }
 
+func TestVisitor_VisitAST_AllowsSkippingASubTreeOfTypedNodes(t *testing.T) {
+
+	query := `{ a, b { x }, c }`
+	astDoc := parse(t, query)
+
+	visited := []interface{}{}
+	expectedVisited := []interface{}{
+		[]interface{}{"enter", "Document", nil},
+		[]interface{}{"enter", "OperationDefinition", nil},
+		[]interface{}{"enter", "SelectionSet", nil},
+		[]interface{}{"enter", "Field", nil},
+		[]interface{}{"enter", "Name", "a"},
+		[]interface{}{"leave", "Name", "a"},
+		[]interface{}{"leave", "Field", nil},
+		[]interface{}{"enter", "Field", nil},
+		[]interface{}{"enter", "Field", nil},
+		[]interface{}{"enter", "Name", "c"},
+		[]interface{}{"leave", "Name", "c"},
+		[]interface{}{"leave", "Field", nil},
+		[]interface{}{"leave", "SelectionSet", nil},
+		[]interface{}{"leave", "OperationDefinition", nil},
+		[]interface{}{"leave", "Document", nil},
+	}
+
+	v := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.Name:
+				visited = append(visited, []interface{}{"enter", node.Kind, node.Value})
+			case ast.Node:
+				visited = append(visited, []interface{}{"enter", node.GetKind(), nil})
+				if node, ok := node.(*ast.Field); ok && node.Name.Value == "b" {
+					return visitor.ActionSkip, nil
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.Name:
+				visited = append(visited, []interface{}{"leave", node.Kind, node.Value})
+			case ast.Node:
+				visited = append(visited, []interface{}{"leave", node.GetKind(), nil})
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+
+	_ = visitor.VisitAST(astDoc, v, nil)
+
+	if !reflect.DeepEqual(visited, expectedVisited) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedVisited, visited))
+	}
+}
+
+func TestVisitor_VisitAST_AllowsForEditingWithoutMutatingTheOriginal(t *testing.T) {
+
+	query := `{ a, b, c { a, b, c } }`
+	astDoc := parse(t, query)
+	originalAST := parse(t, query)
+
+	expectedAST := parse(t, `{ a,    c { a,    c } }`)
+	v := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			if node, ok := p.Node.(*ast.Field); ok && node.Name != nil && node.Name.Value == "b" {
+				return visitor.ActionUpdate, nil
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+
+	editedAST := visitor.VisitAST(astDoc, v, nil)
+	if !reflect.DeepEqual(editedAST, expectedAST) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedAST, editedAST))
+	}
+	if !reflect.DeepEqual(astDoc, originalAST) {
+		t.Fatalf("Expected original AST to be unchanged, Diff: %v", testutil.Diff(originalAST, astDoc))
+	}
+}
+
 func TestVisitor_AllowsEarlyExitWhileVisiting(t *testing.T) {
 
 	visited := []interface{}{}
@@ -263,6 +263,7 @@ func TestLists_NonNullListOfNullableObjectsReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -333,6 +334,7 @@ func TestLists_NonNullListOfNullableFunc_ReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -429,6 +431,7 @@ func TestLists_NullableListOfNonNullObjects_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -494,6 +497,7 @@ func TestLists_NullableListOfNonNullFunc_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -561,9 +565,19 @@ func TestLists_NullableListOfNonNullArrayOfFunc_ContainsNulls(t *testing.T) {
 	expected := &graphql.Result{
 		Data: map[string]interface{}{
 			"nest": map[string]interface{}{
-				"test": []interface{}{
-					1, nil, 2,
+				"test": nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message: "Cannot return null for non-nullable field DataType.test.",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{
+						Line:   1,
+						Column: 10,
+					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -605,6 +619,7 @@ func TestLists_NonNullListOfNonNullObjects_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -626,6 +641,7 @@ func TestLists_NonNullListOfNonNullObjects_ReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -677,6 +693,7 @@ func TestLists_NonNullListOfNonNullFunc_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -703,6 +720,7 @@ func TestLists_NonNullListOfNonNullFunc_ReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -752,10 +770,18 @@ func TestLists_NonNullListOfNonNullArrayOfFunc_ContainsNulls(t *testing.T) {
 	}
 	expected := &graphql.Result{
 		Data: map[string]interface{}{
-			"nest": map[string]interface{}{
-				"test": []interface{}{
-					1, nil, 2,
+			"nest": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message: "Cannot return null for non-nullable field DataType.test.",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{
+						Line:   1,
+						Column: 10,
+					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BatchFn loads the values of many keys at once for a Loader. It returns the
+// values in the order of the keys: a value which is an error fails the load
+// of its key only, while a returned error fails the loads of every key.
+type BatchFn func(keys []interface{}) ([]interface{}, error)
+
+/**
+ * Loader batches and caches the loads of values by key.
+ *
+ * A resolver returning the result of Load defers the completion of its field:
+ * the executor first resolves every other field of the same level, then loads
+ * all the keys requested meanwhile with a single call to the batch function,
+ * and continues the execution with the loaded values.
+ *
+ * Loaded values are cached by key for the lifetime of the loader, which should
+ * thus be created for each request. Keys must be comparable.
+ */
+type Loader struct {
+	batchFn BatchFn
+	mutex   sync.Mutex
+	cache   map[interface{}]*LoadResult
+	batch   *loaderBatch
+}
+
+func NewLoader(batchFn BatchFn) *Loader {
+	return &Loader{
+		batchFn: batchFn,
+		cache:   map[interface{}]*LoadResult{},
+	}
+}
+
+// Load requests the value of the key, it is loaded along with the other keys
+// of its batch once the value of the result is needed.
+func (l *Loader) Load(key interface{}) *LoadResult {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if result, ok := l.cache[key]; ok {
+		return result
+	}
+	if l.batch == nil {
+		l.batch = &loaderBatch{}
+	}
+	result := &LoadResult{
+		loader: l,
+		batch:  l.batch,
+	}
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results = append(l.batch.results, result)
+	l.cache[key] = result
+	return result
+}
+
+// LoadMany requests the values of many keys, the resolver of a list field
+// may return its results as is.
+func (l *Loader) LoadMany(keys []interface{}) []*LoadResult {
+	results := []*LoadResult{}
+	for _, key := range keys {
+		results = append(results, l.Load(key))
+	}
+	return results
+}
+
+// Clear removes the key from the cache, so that the next Load of the key
+// loads it again.
+func (l *Loader) Clear(key interface{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	delete(l.cache, key)
+}
+
+func (l *Loader) dispatch(batch *loaderBatch) {
+	// keys requested from now on belong to the next batch
+	l.mutex.Lock()
+	if l.batch == batch {
+		l.batch = nil
+	}
+	l.mutex.Unlock()
+
+	values, err := l.callBatchFn(batch.keys)
+	if err == nil && len(values) != len(batch.keys) {
+		err = fmt.Errorf("Loader batch function must return a value for each key, "+
+			"got %v values for %v keys.", len(values), len(batch.keys))
+	}
+	for i, result := range batch.results {
+		if err != nil {
+			result.err = err
+			continue
+		}
+		if valueErr, ok := values[i].(error); ok {
+			result.err = valueErr
+			continue
+		}
+		result.value = values[i]
+	}
+}
+
+func (l *Loader) callBatchFn(keys []interface{}) (values []interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return l.batchFn(keys)
+}
+
+type loaderBatch struct {
+	once    sync.Once
+	keys    []interface{}
+	results []*LoadResult
+}
+
+// LoadResult is the deferred result of Loader.Load, it is a Thunk.
+type LoadResult struct {
+	loader *Loader
+	batch  *loaderBatch
+	value  interface{}
+	err    error
+}
+
+// Value returns the loaded value of the key, loading the batch of the key
+// first if it was not loaded yet.
+func (r *LoadResult) Value() (interface{}, error) {
+	r.batch.once.Do(func() {
+		r.loader.dispatch(r.batch)
+	})
+	return r.value, r.err
+}
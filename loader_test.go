@@ -0,0 +1,289 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type loaderTestUser struct {
+	ID        string
+	Name      string
+	FriendIDs []string
+}
+
+var loaderTestUsers = map[string]loaderTestUser{
+	"1": loaderTestUser{ID: "1", Name: "Luke", FriendIDs: []string{"2", "3"}},
+	"2": loaderTestUser{ID: "2", Name: "Han", FriendIDs: []string{"1", "3"}},
+	"3": loaderTestUser{ID: "3", Name: "Leia", FriendIDs: []string{"1", "2", "4"}},
+	"4": loaderTestUser{ID: "4", Name: "Vader"},
+}
+
+// batchRecorder records the keys of every call to its batch function.
+type batchRecorder struct {
+	mutex   sync.Mutex
+	batches [][]string
+}
+
+func (r *batchRecorder) batchFn(keys []interface{}) ([]interface{}, error) {
+	batch := []string{}
+	values := []interface{}{}
+	for _, key := range keys {
+		batch = append(batch, key.(string))
+		if key == "4" {
+			values = append(values, errors.New("User 4 is classified"))
+			continue
+		}
+		values = append(values, loaderTestUsers[key.(string)])
+	}
+	sort.Strings(batch)
+	r.mutex.Lock()
+	r.batches = append(r.batches, batch)
+	r.mutex.Unlock()
+	return values, nil
+}
+
+func newLoaderTestSchema(t *testing.T, userLoader func(p graphql.GQLFRParams) *graphql.Loader) graphql.Schema {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldConfigMap{
+			"name": &graphql.FieldConfig{
+				Type: graphql.String,
+				Resolve: func(p graphql.GQLFRParams) interface{} {
+					return p.Source.(loaderTestUser).Name
+				},
+			},
+		},
+	})
+	userType.AddFieldConfig("friends", &graphql.FieldConfig{
+		Type: graphql.NewList(userType),
+		Resolve: func(p graphql.GQLFRParams) interface{} {
+			keys := []interface{}{}
+			for _, id := range p.Source.(loaderTestUser).FriendIDs {
+				keys = append(keys, id)
+			}
+			return userLoader(p).LoadMany(keys)
+		},
+	})
+	userType.AddFieldConfig("nonNullFriends", &graphql.FieldConfig{
+		Type: graphql.NewList(graphql.NewNonNull(userType)),
+		Resolve: func(p graphql.GQLFRParams) interface{} {
+			keys := []interface{}{}
+			for _, id := range p.Source.(loaderTestUser).FriendIDs {
+				keys = append(keys, id)
+			}
+			return userLoader(p).LoadMany(keys)
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"user": &graphql.FieldConfig{
+					Type: userType,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return userLoader(p).Load(p.Args["id"])
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.FieldConfigMap{
+				"touch": &graphql.FieldConfig{
+					Type: userType,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return userLoader(p).Load(p.Args["id"])
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return schema
+}
+
+func TestLoader_BatchesTheLoadsOfEachLevel(t *testing.T) {
+	for _, concurrency := range []int{0, 4} {
+		recorder := &batchRecorder{}
+		loader := graphql.NewLoader(recorder.batchFn)
+		schema := newLoaderTestSchema(t, func(p graphql.GQLFRParams) *graphql.Loader {
+			return loader
+		})
+
+		query := `{
+          luke: user(id: "1") {
+            name
+            friends {
+              name
+              friends { name }
+            }
+          }
+          han: user(id: "2") { name }
+        }`
+		expected := &graphql.Result{
+			Data: map[string]interface{}{
+				"luke": map[string]interface{}{
+					"name": "Luke",
+					"friends": []interface{}{
+						map[string]interface{}{
+							"name": "Han",
+							"friends": []interface{}{
+								map[string]interface{}{"name": "Luke"},
+								map[string]interface{}{"name": "Leia"},
+							},
+						},
+						map[string]interface{}{
+							"name": "Leia",
+							"friends": []interface{}{
+								map[string]interface{}{"name": "Luke"},
+								map[string]interface{}{"name": "Han"},
+								nil,
+							},
+						},
+					},
+				},
+				"han": map[string]interface{}{
+					"name": "Han",
+				},
+			},
+			Errors: []gqlerrors.FormattedError{
+				gqlerrors.FormattedError{
+					Message: "User 4 is classified",
+					Locations: []location.SourceLocation{
+						location.SourceLocation{Line: 6, Column: 15},
+					},
+					Path: []interface{}{"luke", "friends", 1, "friends", 2},
+				},
+			},
+		}
+		result := testutil.TestExecute(t, graphql.ExecuteParams{
+			Schema:      schema,
+			AST:         testutil.TestParse(t, query),
+			Concurrency: concurrency,
+		})
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+		// cached keys are not loaded again
+		expectedBatches := [][]string{
+			[]string{"1", "2"},
+			[]string{"3"},
+			[]string{"4"},
+		}
+		if !reflect.DeepEqual(expectedBatches, recorder.batches) {
+			t.Fatalf("Unexpected batches, Diff: %v", testutil.Diff(expectedBatches, recorder.batches))
+		}
+	}
+}
+
+func TestLoader_LoadErrorsOfNonNullItemsNullTheList(t *testing.T) {
+	recorder := &batchRecorder{}
+	schema := newLoaderTestSchema(t, func(p graphql.GQLFRParams) *graphql.Loader {
+		return p.Info.RootValue.(*graphql.Loader)
+	})
+
+	query := `{
+      user(id: "3") {
+        name
+        nonNullFriends { name }
+      }
+    }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{
+				"name":           "Leia",
+				"nonNullFriends": nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message: "User 4 is classified",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 4, Column: 9},
+				},
+				Path: []interface{}{"user", "nonNullFriends", 2},
+			},
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, query),
+		Root:   graphql.NewLoader(recorder.batchFn),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestLoader_CompletesEachMutationBeforeTheNextOne(t *testing.T) {
+	recorder := &batchRecorder{}
+	loader := graphql.NewLoader(recorder.batchFn)
+	schema := newLoaderTestSchema(t, func(p graphql.GQLFRParams) *graphql.Loader {
+		return loader
+	})
+
+	query := `mutation M {
+      first: touch(id: "1") { name }
+      second: touch(id: "2") { name }
+    }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"first":  map[string]interface{}{"name": "Luke"},
+			"second": map[string]interface{}{"name": "Han"},
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, query),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	expectedBatches := [][]string{
+		[]string{"1"},
+		[]string{"2"},
+	}
+	if !reflect.DeepEqual(expectedBatches, recorder.batches) {
+		t.Fatalf("Unexpected batches, Diff: %v", testutil.Diff(expectedBatches, recorder.batches))
+	}
+}
+
+func TestLoader_ReportsBatchFunctionErrorsForEveryKey(t *testing.T) {
+	loader := graphql.NewLoader(func(keys []interface{}) ([]interface{}, error) {
+		return []interface{}{"only one"}, nil
+	})
+	first, second := loader.Load("a"), loader.Load("b")
+	expectedError := "Loader batch function must return a value for each key, got 1 values for 2 keys."
+	for _, result := range []*graphql.LoadResult{first, second} {
+		if _, err := result.Value(); err == nil || err.Error() != expectedError {
+			t.Fatalf("Unexpected error, expected %v, got %v", expectedError, err)
+		}
+	}
+
+	loader = graphql.NewLoader(func(keys []interface{}) ([]interface{}, error) {
+		panic("backend unavailable")
+	})
+	if _, err := loader.Load("a").Value(); err == nil || err.Error() != "backend unavailable" {
+		t.Fatalf("Unexpected error, expected backend unavailable, got %v", err)
+	}
+	loader.Clear("a")
+	if loader.Load("a") == loader.Load("b") {
+		t.Fatalf("expected different results for different keys")
+	}
+}
@@ -14,13 +14,17 @@ func NewLocatedError(err interface{}, nodes []ast.Node) *gqlerrors.Error {
 		message = err
 	}
 	stack := message
-	return gqlerrors.NewError(
+	located := gqlerrors.NewError(
 		message,
 		nodes,
 		stack,
 		nil,
 		[]int{},
 	)
+	if err, ok := err.(error); ok {
+		located.OriginalError = err
+	}
+	return located
 }
 
 func FieldASTsToNodeASTs(fieldASTs []*ast.Field) []ast.Node {
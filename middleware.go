@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+/**
+ * FieldMiddleware wraps the resolve function of every field of an execution,
+ * see ExecuteParams.Middlewares. It returns a resolve function which usually
+ * calls next, the resolve function of the field wrapped by the following
+ * middlewares, and may check or alter its params and result, or resolve the
+ * field without calling next at all.
+ *
+ * The params carry the ResolveInfo of the field, whose Path is the path of the
+ * field in the response. A middleware reports an error by panicking with it,
+ * or by returning a Thunk failing with it, like any resolve function.
+ */
+type FieldMiddleware func(next FieldResolveFn) FieldResolveFn
+
+// resolveFnOf returns the resolve function of the field definition, wrapped
+// by the directives of the field AST, and then by the middlewares of the
+// execution, the first middleware being the outermost one.
+func resolveFnOf(eCtx *ExecutionContext, fieldDef *FieldDefinition, fieldAST *ast.Field) FieldResolveFn {
+	resolveFn := fieldDef.Resolve
+	if resolveFn == nil && fieldDef.ResolveWithError != nil {
+		resolveFn = resolveWithErrorFn(fieldDef.ResolveWithError)
+	}
+	if resolveFn == nil {
+		resolveFn = defaultResolveFn
+	}
+	for _, directiveAST := range fieldAST.Directives {
+		if directiveAST == nil || directiveAST.Name == nil {
+			continue
+		}
+		directive := eCtx.Schema.GetDirective(directiveAST.Name.Value)
+		if directive == nil || directive.Resolve == nil {
+			continue
+		}
+		resolveFn = resolveWithDirectiveFn(eCtx, directive, directiveAST, resolveFn)
+	}
+	for i := len(eCtx.middlewares) - 1; i >= 0; i-- {
+		resolveFn = eCtx.middlewares[i](resolveFn)
+	}
+	return resolveFn
+}
+
+// resolveWithDirectiveFn wraps the resolve function with the directive.
+func resolveWithDirectiveFn(eCtx *ExecutionContext, directive *Directive, directiveAST *ast.Directive, next FieldResolveFn) FieldResolveFn {
+	return func(p GQLFRParams) interface{} {
+		args, err := getArgumentValues(directive.Args, directiveAST.Arguments, eCtx.VariableValues)
+		if err != nil {
+			panic(NewLocatedError(err, []ast.Node{directiveAST}))
+		}
+		return directive.Resolve(DirectiveResolveParams{
+			Args:  args,
+			Field: p,
+			Next:  next,
+		})
+	}
+}
+
+// resolveWithErrorFn adapts a FieldResolveFnWithError, the errors it returns
+// are raised like the panics of a FieldResolveFn.
+func resolveWithErrorFn(resolveFn FieldResolveFnWithError) FieldResolveFn {
+	return func(p GQLFRParams) interface{} {
+		resolved, err := resolveFn(p)
+		if err != nil {
+			// Errors returned by the resolve function are handled exactly like
+			// panics, nulling the field or propagating to the parent field.
+			panic(NewLocatedError(err, FieldASTsToNodeASTs(p.Info.FieldASTs)))
+		}
+		return resolved
+	}
+}
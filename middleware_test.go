@@ -0,0 +1,198 @@
+package graphql_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestMiddlewares_WrapEveryResolverInOrder(t *testing.T) {
+	var mu sync.Mutex
+	calls := []string{}
+	record := func(name string) graphql.FieldMiddleware {
+		return func(next graphql.FieldResolveFn) graphql.FieldResolveFn {
+			return func(p graphql.GQLFRParams) interface{} {
+				mu.Lock()
+				calls = append(calls, fmt.Sprintf("%v %v", name, p.Info.Path.AsArray()))
+				mu.Unlock()
+				return next(p)
+			}
+		}
+	}
+	upper := func(next graphql.FieldResolveFn) graphql.FieldResolveFn {
+		return func(p graphql.GQLFRParams) interface{} {
+			value := next(p)
+			if value, ok := value.(string); ok {
+				return strings.ToUpper(value)
+			}
+			return value
+		}
+	}
+
+	query := `{ hero { name friends { name } } }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "R2-D2",
+				"friends": []interface{}{
+					map[string]interface{}{"name": "LUKE SKYWALKER"},
+					map[string]interface{}{"name": "HAN SOLO"},
+					map[string]interface{}{"name": "LEIA ORGANA"},
+				},
+			},
+		},
+	}
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: query,
+		Middlewares: []graphql.FieldMiddleware{
+			record("outer"),
+			record("inner"),
+			func(next graphql.FieldResolveFn) graphql.FieldResolveFn {
+				return func(p graphql.GQLFRParams) interface{} {
+					// the name of the hero is left untouched
+					if p.Info.Path.Prev != nil && p.Info.Path.Prev.Key == "hero" {
+						return next(p)
+					}
+					return upper(next)(p)
+				}
+			},
+		},
+	}))
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	expectedCalls := []string{
+		"outer [hero]",
+		"inner [hero]",
+		"outer [hero name]",
+		"inner [hero name]",
+		"outer [hero friends]",
+		"inner [hero friends]",
+		"outer [hero friends 0 name]",
+		"inner [hero friends 0 name]",
+		"outer [hero friends 1 name]",
+		"inner [hero friends 1 name]",
+		"outer [hero friends 2 name]",
+		"inner [hero friends 2 name]",
+	}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Fatalf("Unexpected calls, Diff: %v", testutil.Diff(expectedCalls, calls))
+	}
+}
+
+func TestMiddlewares_ReportErrorsLikeResolvers(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"public": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return "public"
+					},
+				},
+				"secret": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return "secret"
+					},
+				},
+				"broken": &graphql.FieldConfig{
+					Type: graphql.String,
+					ResolveWithError: func(p graphql.GQLFRParams) (interface{}, error) {
+						return nil, errors.New("broken")
+					},
+				},
+				"panicking": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						panic(fmt.Errorf("index out of range"))
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	authorize := func(next graphql.FieldResolveFn) graphql.FieldResolveFn {
+		return func(p graphql.GQLFRParams) interface{} {
+			if p.Info.FieldName == "secret" {
+				panic(errors.New("Not authorized to access secret"))
+			}
+			return next(p)
+		}
+	}
+	// translates the panics of resolvers into errors hiding their details
+	recoverPanics := func(next graphql.FieldResolveFn) graphql.FieldResolveFn {
+		return func(p graphql.GQLFRParams) (result interface{}) {
+			defer func() {
+				if r := recover(); r != nil {
+					// errors raised by the execution are left untouched
+					if _, ok := r.(*gqlerrors.Error); ok {
+						panic(r)
+					}
+					result = graphql.ThunkFn(func() (interface{}, error) {
+						return nil, errors.New("Internal error")
+					})
+				}
+			}()
+			return next(p)
+		}
+	}
+
+	query := `{
+      public
+      secret
+      broken
+      panicking
+    }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"public":    "public",
+			"secret":    nil,
+			"broken":    nil,
+			"panicking": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message:   "Not authorized to access secret",
+				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"secret"},
+			},
+			gqlerrors.FormattedError{
+				Message: "broken",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 4, Column: 7},
+				},
+				Path: []interface{}{"broken"},
+			},
+			gqlerrors.FormattedError{
+				Message: "Internal error",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 5, Column: 7},
+				},
+				Path: []interface{}{"panicking"},
+			},
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema:      schema,
+		AST:         testutil.TestParse(t, query),
+		Middlewares: []graphql.FieldMiddleware{authorize, recoverPanics},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -2,7 +2,9 @@ package graphql_test
 
 import (
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/gqlerrors"
@@ -176,6 +178,126 @@ func TestMutations_ExecutionOrdering_EvaluatesMutationsSerially(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+func TestMutations_ExecutionOrdering_EvaluatesMutationsInDocumentOrder(t *testing.T) {
+
+	root := newTestRoot(6)
+	doc := `mutation M {
+      third: immediatelyChangeTheNumber(newNumber: 3) {
+        theNumber
+      },
+      first: immediatelyChangeTheNumber(newNumber: 1) {
+        theNumber
+      },
+      fifth: promiseToChangeTheNumber(newNumber: 5) {
+        theNumber
+      },
+      second: immediatelyChangeTheNumber(newNumber: 2) {
+        theNumber
+      },
+      fourth: promiseToChangeTheNumber(newNumber: 4) {
+        theNumber
+      }
+    }`
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema: mutationsTestSchema,
+		AST:    testutil.TestParse(t, doc),
+		Root:   root,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	// the last mutation of the document runs last
+	if root.NumberHolder.TheNumber != 4 {
+		t.Fatalf("Unexpected number, expected 4, got %v", root.NumberHolder.TheNumber)
+	}
+	expectedKeys := []string{"third", "first", "fifth", "second", "fourth"}
+	if keys := result.Data.(*graphql.OrderedMap).Keys(); !reflect.DeepEqual(expectedKeys, keys) {
+		t.Fatalf("Unexpected result keys, Diff: %v", testutil.Diff(expectedKeys, keys))
+	}
+}
+
+func TestMutations_ExecutionOrdering_EvaluatesMutationsSeriallyWithConcurrency(t *testing.T) {
+
+	var mutex sync.Mutex
+	active := 0
+	overlapped := false
+	changeTheNumber := func(p graphql.GQLFRParams) interface{} {
+		mutex.Lock()
+		active++
+		overlapped = overlapped || active > 1
+		mutex.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mutex.Lock()
+		active--
+		mutex.Unlock()
+		newNumber, _ := p.Args["newNumber"].(int)
+		return &testNumberHolder{newNumber}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"numberHolder": &graphql.FieldConfig{
+					Type: numberHolderType,
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.FieldConfigMap{
+				"changeTheNumber": &graphql.FieldConfig{
+					Type: numberHolderType,
+					Args: graphql.FieldConfigArgument{
+						"newNumber": &graphql.ArgumentConfig{
+							Type: graphql.Int,
+						},
+					},
+					Resolve: changeTheNumber,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	doc := `mutation M {
+      first: changeTheNumber(newNumber: 1) {
+        theNumber
+      },
+      second: changeTheNumber(newNumber: 2) {
+        theNumber
+      },
+      third: changeTheNumber(newNumber: 3) {
+        theNumber
+      }
+    }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"first": map[string]interface{}{
+				"theNumber": 1,
+			},
+			"second": map[string]interface{}{
+				"theNumber": 2,
+			},
+			"third": map[string]interface{}{
+				"theNumber": 3,
+			},
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema:      schema,
+		AST:         testutil.TestParse(t, doc),
+		Concurrency: 4,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if overlapped {
+		t.Fatalf("Expected mutations not to be resolved concurrently")
+	}
+}
 func TestMutations_EvaluatesMutationsCorrectlyInThePresenceOfAFailedMutation(t *testing.T) {
 
 	root := newTestRoot(6)
@@ -128,6 +128,7 @@ func TestNonNull_NullsANullableFieldThatThrowsSynchronously(t *testing.T) {
 						Line: 3, Column: 9,
 					},
 				},
+				Path: []interface{}{"sync"},
 			},
 		},
 	}
@@ -166,6 +167,7 @@ func TestNonNull_NullsANullableFieldThatThrowsInAPromise(t *testing.T) {
 						Line: 3, Column: 9,
 					},
 				},
+				Path: []interface{}{"promise"},
 			},
 		},
 	}
@@ -206,6 +208,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANullableFieldThat
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"nest", "nonNullSync"},
 			},
 		},
 	}
@@ -246,6 +249,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"nest", "nonNullPromise"},
 			},
 		},
 	}
@@ -286,6 +290,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"promiseNest", "nonNullSync"},
 			},
 		},
 	}
@@ -326,6 +331,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"promiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -409,72 +415,84 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"nest", "sync"},
 			},
 			gqlerrors.FormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 7, Column: 13},
 				},
+				Path: []interface{}{"nest", "nest", "sync"},
 			},
 			gqlerrors.FormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 11, Column: 13},
 				},
+				Path: []interface{}{"nest", "promiseNest", "sync"},
 			},
 			gqlerrors.FormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 16, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "sync"},
 			},
 			gqlerrors.FormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 19, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "nest", "sync"},
 			},
 			gqlerrors.FormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 23, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "promiseNest", "sync"},
 			},
 			gqlerrors.FormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 5, Column: 11},
 				},
+				Path: []interface{}{"nest", "promise"},
 			},
 			gqlerrors.FormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 8, Column: 13},
 				},
+				Path: []interface{}{"nest", "nest", "promise"},
 			},
 			gqlerrors.FormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 12, Column: 13},
 				},
+				Path: []interface{}{"nest", "promiseNest", "promise"},
 			},
 			gqlerrors.FormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 17, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "promise"},
 			},
 			gqlerrors.FormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 20, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "nest", "promise"},
 			},
 			gqlerrors.FormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 24, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "promiseNest", "promise"},
 			},
 		},
 	}
@@ -562,24 +580,28 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldThrowsInALongChainOfField
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 8, Column: 19},
 				},
+				Path: []interface{}{"nest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			gqlerrors.FormattedError{
 				Message: nonNullSyncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 19, Column: 19},
 				},
+				Path: []interface{}{"promiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			gqlerrors.FormattedError{
 				Message: nonNullPromiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 30, Column: 19},
 				},
+				Path: []interface{}{"anotherNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 			gqlerrors.FormattedError{
 				Message: nonNullPromiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 41, Column: 19},
 				},
+				Path: []interface{}{"anotherPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -686,6 +708,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"nest", "nonNullSync"},
 			},
 		},
 	}
@@ -724,6 +747,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"nest", "nonNullPromise"},
 			},
 		},
 	}
@@ -763,6 +787,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "nonNullSync"},
 			},
 		},
 	}
@@ -801,6 +826,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -960,24 +986,28 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 8, Column: 19},
 				},
+				Path: []interface{}{"nest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			gqlerrors.FormattedError{
 				Message: `Cannot return null for non-nullable field DataType.nonNullSync.`,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 19, Column: 19},
 				},
+				Path: []interface{}{"promiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			gqlerrors.FormattedError{
 				Message: `Cannot return null for non-nullable field DataType.nonNullPromise.`,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 30, Column: 19},
 				},
+				Path: []interface{}{"anotherNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 			gqlerrors.FormattedError{
 				Message: `Cannot return null for non-nullable field DataType.nonNullPromise.`,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 41, Column: 19},
 				},
+				Path: []interface{}{"anotherPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -1016,6 +1046,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldThrows(t *testing.T) {
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullSync"},
 			},
 		},
 	}
@@ -1048,6 +1079,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldErrors(t *testing.T) {
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullPromise"},
 			},
 		},
 	}
@@ -1080,6 +1112,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldReturnsNull(t *testing.T)
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullSync"},
 			},
 		},
 	}
@@ -1112,6 +1145,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldResolvesNull(t *testing.T
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullPromise"},
 			},
 		},
 	}
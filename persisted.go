@@ -0,0 +1,293 @@
+package graphql
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+)
+
+/**
+ * PersistedQueryStore stores query strings by their persisted query hash, the
+ * hex-encoded SHA-256 hash of their text, see HashQuery.
+ *
+ * Stores must be safe for concurrent use.
+ */
+type PersistedQueryStore interface {
+	// Get returns the query string stored for the hash, and whether it was
+	// found.
+	Get(hash string) (string, bool)
+	// Put stores the query string for the hash.
+	Put(hash string, query string) error
+}
+
+// HashQuery returns the persisted query hash of a query string.
+func HashQuery(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// isQueryHash reports whether the hash is a hex-encoded SHA-256 hash.
+func isQueryHash(hash string) bool {
+	if len(hash) != 2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+/**
+ * MemoryPersistedQueryStore stores the query strings in memory.
+ *
+ * A store returned by NewMemoryPersistedQueryStore is unbounded, which suits
+ * an allowlist, but lets clients of the automatic persisted query protocol
+ * grow it with every distinct query they send. A store returned by
+ * NewLRUPersistedQueryStore holds at most its capacity of queries instead,
+ * and evicts the least recently used query to store a new one.
+ */
+type MemoryPersistedQueryStore struct {
+	capacity int
+
+	mu      sync.Mutex
+	queries map[string]*list.Element
+	recency *list.List
+}
+
+type persistedQueryEntry struct {
+	hash  string
+	query string
+}
+
+// NewMemoryPersistedQueryStore returns an unbounded memory store holding the
+// queries.
+func NewMemoryPersistedQueryStore(queries ...string) *MemoryPersistedQueryStore {
+	store := &MemoryPersistedQueryStore{
+		queries: map[string]*list.Element{},
+		recency: list.New(),
+	}
+	for _, query := range queries {
+		store.Put(HashQuery(query), query)
+	}
+	return store
+}
+
+// NewLRUPersistedQueryStore returns a memory store of at most capacity
+// queries.
+func NewLRUPersistedQueryStore(capacity int) *MemoryPersistedQueryStore {
+	if capacity < 1 {
+		capacity = 1
+	}
+	store := NewMemoryPersistedQueryStore()
+	store.capacity = capacity
+	return store
+}
+
+// Len returns the number of queries of the store.
+func (store *MemoryPersistedQueryStore) Len() int {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.recency.Len()
+}
+
+func (store *MemoryPersistedQueryStore) Get(hash string) (string, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	element, ok := store.queries[hash]
+	if !ok {
+		return "", false
+	}
+	store.recency.MoveToFront(element)
+	return element.Value.(*persistedQueryEntry).query, true
+}
+
+func (store *MemoryPersistedQueryStore) Put(hash string, query string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if element, ok := store.queries[hash]; ok {
+		element.Value.(*persistedQueryEntry).query = query
+		store.recency.MoveToFront(element)
+		return nil
+	}
+	store.queries[hash] = store.recency.PushFront(&persistedQueryEntry{
+		hash:  hash,
+		query: query,
+	})
+	for store.capacity > 0 && store.recency.Len() > store.capacity {
+		oldest := store.recency.Back()
+		store.recency.Remove(oldest)
+		delete(store.queries, oldest.Value.(*persistedQueryEntry).hash)
+	}
+	return nil
+}
+
+// DefaultMaxPersistedQueryFiles is the MaxQueries of the stores returned by
+// NewFilePersistedQueryStore.
+const DefaultMaxPersistedQueryFiles = 10000
+
+/**
+ * FilePersistedQueryStore stores each query string in a file of its
+ * directory, named after its hash with a ".graphql" extension, so that an
+ * allowlist of queries may be deployed as a directory of files.
+ *
+ * Unless the allowlist-only mode is on, clients of the automatic persisted
+ * query protocol add a file for every distinct query they send, so Put
+ * refuses to store a new query once the directory holds MaxQueries queries.
+ * The queries already stored are still served and may be stored again.
+ */
+type FilePersistedQueryStore struct {
+	Dir string
+	// MaxQueries is the maximum number of query files of the directory, it
+	// is not bounded when it is 0.
+	MaxQueries int
+
+	// mu serializes the Puts of the store, so that concurrent Puts cannot
+	// exceed MaxQueries. Stores of a same directory in several processes
+	// may still exceed it by as many queries as there are processes.
+	mu sync.Mutex
+}
+
+// NewFilePersistedQueryStore returns a store of the query files of the
+// directory, which is created by the first Put when missing. It holds at
+// most DefaultMaxPersistedQueryFiles queries.
+func NewFilePersistedQueryStore(dir string) *FilePersistedQueryStore {
+	return &FilePersistedQueryStore{
+		Dir:        dir,
+		MaxQueries: DefaultMaxPersistedQueryFiles,
+	}
+}
+
+func (store *FilePersistedQueryStore) path(hash string) string {
+	return filepath.Join(store.Dir, hash+".graphql")
+}
+
+func (store *FilePersistedQueryStore) Get(hash string) (string, bool) {
+	if !isQueryHash(hash) {
+		return "", false
+	}
+	query, err := ioutil.ReadFile(store.path(hash))
+	if err != nil {
+		return "", false
+	}
+	return string(query), true
+}
+
+// isFull reports whether the directory holds MaxQueries query files.
+func (store *FilePersistedQueryStore) isFull() (bool, error) {
+	if store.MaxQueries <= 0 {
+		return false, nil
+	}
+	files, err := ioutil.ReadDir(store.Dir)
+	if err != nil {
+		return false, err
+	}
+	count := 0
+	for _, file := range files {
+		if !file.IsDir() && filepath.Ext(file.Name()) == ".graphql" {
+			count++
+		}
+	}
+	return count >= store.MaxQueries, nil
+}
+
+func (store *FilePersistedQueryStore) Put(hash string, query string) error {
+	if !isQueryHash(hash) {
+		return gqlerrors.NewFormattedError(`Invalid persisted query hash "` + hash + `".`)
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if err := os.MkdirAll(store.Dir, 0755); err != nil {
+		return err
+	}
+	if _, err := os.Stat(store.path(hash)); os.IsNotExist(err) {
+		full, err := store.isFull()
+		if err != nil {
+			return err
+		}
+		if full {
+			return gqlerrors.NewFormattedError(fmt.Sprintf(
+				"Persisted query store is full, it holds %v queries.", store.MaxQueries))
+		}
+	}
+	// the query file is renamed once written, so that it is never read partially
+	file, err := ioutil.TempFile(store.Dir, hash+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteString(query)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), store.path(hash))
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+	return err
+}
+
+func newPersistedQueryError(message string, code string) gqlerrors.FormattedError {
+	return gqlerrors.FormattedError{
+		Message:    message,
+		Locations:  []location.SourceLocation{},
+		Extensions: map[string]interface{}{"code": code},
+	}
+}
+
+/**
+ * Resolves the request string of the params from their persisted query store,
+ * following the automatic persisted query protocol:
+ *
+ *   - a persisted query hash without request string executes the stored
+ *     query, or fails with a "PersistedQueryNotFound" error, so that the
+ *     client sends the request string along with its hash again;
+ *   - a persisted query hash with a request string stores the request string,
+ *     which must match the hash, and executes it.
+ *
+ * In allowlist-only mode, request strings are never stored, and only the
+ * stored queries are resolved.
+ */
+func resolvePersistedQuery(p Params) (string, *gqlerrors.FormattedError) {
+	if p.PersistedQueryHash == "" && !p.AllowlistOnly {
+		return p.RequestString, nil
+	}
+	if p.PersistedQueries == nil {
+		err := newPersistedQueryError("PersistedQueryNotSupported", "PERSISTED_QUERY_NOT_SUPPORTED")
+		return "", &err
+	}
+
+	hash := p.PersistedQueryHash
+	if p.RequestString != "" {
+		if hash == "" {
+			hash = HashQuery(p.RequestString)
+		} else if hash != HashQuery(p.RequestString) {
+			err := newPersistedQueryError("Provided sha256Hash does not match the query.",
+				"PERSISTED_QUERY_HASH_MISMATCH")
+			return "", &err
+		}
+	}
+
+	query, ok := p.PersistedQueries.Get(hash)
+	switch {
+	case ok:
+		return query, nil
+	case p.AllowlistOnly:
+		err := newPersistedQueryError("Query is not in the persisted query allowlist.",
+			"PERSISTED_QUERY_NOT_ALLOWED")
+		return "", &err
+	case p.RequestString == "":
+		err := newPersistedQueryError("PersistedQueryNotFound", "PERSISTED_QUERY_NOT_FOUND")
+		return "", &err
+	}
+	// a query which cannot be stored is executed all the same, the client
+	// sends it again along with its hash next time
+	p.PersistedQueries.Put(hash, p.RequestString)
+	return p.RequestString, nil
+}
@@ -0,0 +1,242 @@
+package graphql_test
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func persistedQueryError(message string, code string) *graphql.Result {
+	return &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message:    message,
+				Locations:  []location.SourceLocation{},
+				Extensions: map[string]interface{}{"code": code},
+			},
+		},
+	}
+}
+
+func TestPersistedQueries_FollowsTheAutomaticPersistedQueryProtocol(t *testing.T) {
+	store := graphql.NewMemoryPersistedQueryStore()
+	query := `{ hero { name } }`
+	hash := graphql.HashQuery(query)
+	if empty := graphql.HashQuery(""); empty != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Fatalf("Unexpected hash: %v", empty)
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{"name": "R2-D2"},
+		},
+	}
+
+	tests := []struct {
+		requestString string
+		hash          string
+		expected      *graphql.Result
+	}{
+		// the client sends the hash alone first
+		{"", hash, persistedQueryError("PersistedQueryNotFound", "PERSISTED_QUERY_NOT_FOUND")},
+		// then the query along with its hash, which is stored
+		{query, hash, expected},
+		// then the hash alone again
+		{"", hash, expected},
+		{`{ hero { id } }`, hash, persistedQueryError(
+			"Provided sha256Hash does not match the query.", "PERSISTED_QUERY_HASH_MISMATCH")},
+		// queries without hash are not stored
+		{`{ hero { id } }`, "", &graphql.Result{
+			Data: map[string]interface{}{
+				"hero": map[string]interface{}{"id": "2001"},
+			},
+		}},
+	}
+	for _, test := range tests {
+		result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+			Schema:             testutil.StarWarsSchema,
+			RequestString:      test.requestString,
+			PersistedQueries:   store,
+			PersistedQueryHash: test.hash,
+		}))
+		if !reflect.DeepEqual(test.expected, result) {
+			t.Fatalf("Unexpected result for %q %v, Diff: %v", test.requestString, test.hash, testutil.Diff(test.expected, result))
+		}
+	}
+	if _, ok := store.Get(graphql.HashQuery(`{ hero { id } }`)); ok {
+		t.Fatalf("Unexpected stored query without hash")
+	}
+
+	result := graphql.Graphql(graphql.Params{
+		Schema:             testutil.StarWarsSchema,
+		PersistedQueryHash: hash,
+	})
+	expectedErr := persistedQueryError("PersistedQueryNotSupported", "PERSISTED_QUERY_NOT_SUPPORTED")
+	if !reflect.DeepEqual(expectedErr, result) {
+		t.Fatalf("Unexpected result without store, Diff: %v", testutil.Diff(expectedErr, result))
+	}
+}
+
+func TestPersistedQueries_RefusesQueriesOutOfTheAllowlist(t *testing.T) {
+	allowed := `{ hero { name } }`
+	store := graphql.NewMemoryPersistedQueryStore(allowed)
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{"name": "R2-D2"},
+		},
+	}
+	refused := persistedQueryError("Query is not in the persisted query allowlist.", "PERSISTED_QUERY_NOT_ALLOWED")
+
+	tests := []struct {
+		requestString string
+		hash          string
+		expected      *graphql.Result
+	}{
+		{allowed, "", expected},
+		{"", graphql.HashQuery(allowed), expected},
+		{allowed, graphql.HashQuery(allowed), expected},
+		{`{ hero { id } }`, "", refused},
+		{`{ hero { id } }`, graphql.HashQuery(`{ hero { id } }`), refused},
+		{"", graphql.HashQuery(`{ hero { id } }`), refused},
+	}
+	for _, test := range tests {
+		result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+			Schema:             testutil.StarWarsSchema,
+			RequestString:      test.requestString,
+			PersistedQueries:   store,
+			PersistedQueryHash: test.hash,
+			AllowlistOnly:      true,
+		}))
+		if !reflect.DeepEqual(test.expected, result) {
+			t.Fatalf("Unexpected result for %q %v, Diff: %v", test.requestString, test.hash, testutil.Diff(test.expected, result))
+		}
+	}
+	if _, ok := store.Get(graphql.HashQuery(`{ hero { id } }`)); ok {
+		t.Fatalf("Unexpected stored query in allowlist-only mode")
+	}
+}
+
+func TestLRUPersistedQueryStore_EvictsTheLeastRecentlyUsedQueries(t *testing.T) {
+	store := graphql.NewLRUPersistedQueryStore(2)
+	queries := []string{`{ hero { name } }`, `{ hero { id } }`, `{ hero { friends { name } } }`}
+	register := func(query string) *graphql.Result {
+		return graphql.Graphql(graphql.Params{
+			Schema:             testutil.StarWarsSchema,
+			RequestString:      query,
+			PersistedQueries:   store,
+			PersistedQueryHash: graphql.HashQuery(query),
+		})
+	}
+
+	register(queries[0])
+	register(queries[1])
+	// using the first query makes the second one the least recently used
+	if _, ok := store.Get(graphql.HashQuery(queries[0])); !ok {
+		t.Fatalf("Expected the first query to be stored")
+	}
+	register(queries[2])
+	if store.Len() != 2 {
+		t.Fatalf("Unexpected number of stored queries: %v", store.Len())
+	}
+	for i, expected := range []bool{true, false, true} {
+		if _, ok := store.Get(graphql.HashQuery(queries[i])); ok != expected {
+			t.Fatalf("Unexpected stored query %v: %v", i, ok)
+		}
+	}
+
+	// the evicted query is registered again by the protocol
+	result := graphql.Graphql(graphql.Params{
+		Schema:             testutil.StarWarsSchema,
+		PersistedQueries:   store,
+		PersistedQueryHash: graphql.HashQuery(queries[1]),
+	})
+	expected := persistedQueryError("PersistedQueryNotFound", "PERSISTED_QUERY_NOT_FOUND")
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if result := register(queries[1]); result.HasErrors() {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestFilePersistedQueryStore_StoresQueriesInFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "persisted")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	query := `{ hero { name } }`
+	hash := graphql.HashQuery(query)
+	store := graphql.NewFilePersistedQueryStore(dir + "/queries")
+	if _, ok := store.Get(hash); ok {
+		t.Fatalf("Unexpected query before Put")
+	}
+	if err := store.Put(hash, query); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	content, err := ioutil.ReadFile(dir + "/queries/" + hash + ".graphql")
+	if err != nil || string(content) != query {
+		t.Fatalf("Unexpected query file: %q, %v", content, err)
+	}
+	// a new store of the directory finds the query
+	if stored, ok := graphql.NewFilePersistedQueryStore(dir + "/queries").Get(hash); !ok || stored != query {
+		t.Fatalf("Unexpected stored query: %q, %v", stored, ok)
+	}
+	if err := store.Put("../escape", query); err == nil {
+		t.Fatalf("Expected an error storing an invalid hash")
+	}
+	if _, ok := store.Get("../queries/" + hash); ok {
+		t.Fatalf("Unexpected query of an invalid hash")
+	}
+}
+
+func TestFilePersistedQueryStore_RegistrationsCannotExceedMaxQueries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "persisted")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := graphql.NewFilePersistedQueryStore(dir)
+	store.MaxQueries = 2
+	queries := []string{`{ hero { name } }`, `{ hero { id } }`, `{ hero { friends { name } } }`}
+	for _, query := range append(queries, queries[0]) {
+		result := graphql.Graphql(graphql.Params{
+			Schema:             testutil.StarWarsSchema,
+			RequestString:      query,
+			PersistedQueries:   store,
+			PersistedQueryHash: graphql.HashQuery(query),
+		})
+		// a query which cannot be stored is executed all the same
+		if result.HasErrors() {
+			t.Fatalf("Unexpected errors: %v", result.Errors)
+		}
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Unexpected number of files: %v", len(files))
+	}
+	for i, expected := range []bool{true, true, false} {
+		if _, ok := store.Get(graphql.HashQuery(queries[i])); ok != expected {
+			t.Fatalf("Unexpected stored query %v: %v", i, ok)
+		}
+	}
+	if err := store.Put(graphql.HashQuery(queries[2]), queries[2]); err == nil ||
+		err.Error() != "Persisted query store is full, it holds 2 queries." {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// stored queries may be stored again
+	if err := store.Put(graphql.HashQuery(queries[1]), queries[1]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
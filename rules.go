@@ -0,0 +1,1569 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+/**
+ * A ValidationRule is called once per validated document and returns the
+ * visitor functions which check the document against that rule. Rules are
+ * visited in parallel, alongside a TypeInfo-style ValidationContext which
+ * tracks the current parent type, field definition and input type, and
+ * through which errors are reported.
+ *
+ * Custom rules can be run in addition to the specified ones:
+ *
+ *     rules := append([]ValidationRule{}, SpecifiedRules...)
+ *     rules = append(rules, myRule)
+ *     result := ValidateDocumentWithRules(schema, astDoc, rules)
+ */
+type ValidationRule func(context *ValidationContext) *visitor.VisitorOptions
+
+/**
+ * This set includes all validation rules defined by the GraphQL spec.
+ */
+var SpecifiedRules = []ValidationRule{
+	UniqueOperationNamesRule,
+	LoneAnonymousOperationRule,
+	SingleFieldSubscriptionsRule,
+	KnownTypeNamesRule,
+	FragmentsOnCompositeTypesRule,
+	VariablesAreInputTypesRule,
+	ScalarLeafsRule,
+	FieldsOnCorrectTypeRule,
+	UniqueFragmentNamesRule,
+	KnownFragmentNamesRule,
+	NoUnusedFragmentsRule,
+	PossibleFragmentSpreadsRule,
+	NoFragmentCyclesRule,
+	NoUndefinedVariablesRule,
+	NoUnusedVariablesRule,
+	KnownDirectivesRule,
+	KnownArgumentNamesRule,
+	UniqueArgumentNamesRule,
+	ArgumentsOfCorrectTypeRule,
+	ProvidedNonNullArgumentsRule,
+	DefaultValuesOfCorrectTypeRule,
+	VariablesInAllowedPositionRule,
+	OverlappingFieldsCanBeMergedRule,
+	UniqueInputFieldNamesRule,
+}
+
+func reportError(context *ValidationContext, message string, nodes []ast.Node) (string, interface{}) {
+	context.ReportError(NewLocatedError(message, nodes))
+	return visitor.ActionNoChange, nil
+}
+
+/**
+ * Argument values of correct type
+ *
+ * A GraphQL document is only valid if all field argument literal values are
+ * of the type expected by their position.
+ */
+func ArgumentsOfCorrectTypeRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Argument: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					argAST, ok := p.Node.(*ast.Argument)
+					if !ok || argAST.Value == nil {
+						return visitor.ActionSkip, nil
+					}
+					argDef := context.GetArgument()
+					if argDef != nil && !isValidLiteralValue(argDef.Type, argAST.Value) {
+						argName := ""
+						if argAST.Name != nil {
+							argName = argAST.Name.Value
+						}
+						reportError(
+							context,
+							fmt.Sprintf(`Argument "%v" expected type "%v" but got: %v.`,
+								argName, argDef.Type, printer.Print(argAST.Value)),
+							[]ast.Node{argAST.Value},
+						)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Variable default values of correct type
+ *
+ * A GraphQL document is only valid if all variable default values are of the
+ * type expected by their definition.
+ */
+func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.VariableDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					varDefAST, ok := p.Node.(*ast.VariableDefinition)
+					if !ok {
+						return visitor.ActionSkip, nil
+					}
+					name := ""
+					if varDefAST.Variable != nil && varDefAST.Variable.Name != nil {
+						name = varDefAST.Variable.Name.Value
+					}
+					defaultValueAST := varDefAST.DefaultValue
+					ttype := context.GetInputType()
+					if ttype, ok := ttype.(*NonNull); ok && defaultValueAST != nil {
+						reportError(
+							context,
+							fmt.Sprintf(`Variable "$%v" of type "%v" is required and will not use the default value. `+
+								`Perhaps you meant to use type "%v".`, name, ttype, ttype.OfType),
+							[]ast.Node{defaultValueAST},
+						)
+					}
+					if ttype != nil && defaultValueAST != nil && !isValidLiteralValue(ttype, defaultValueAST) {
+						reportError(
+							context,
+							fmt.Sprintf(`Variable "$%v" of type "%v" has invalid default value: %v.`,
+								name, ttype, printer.Print(defaultValueAST)),
+							[]ast.Node{defaultValueAST},
+						)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.SelectionSet: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.FragmentDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Fields on correct type
+ *
+ * A GraphQL document is only valid if all fields selected are defined by the
+ * parent type, or are an allowed meta field such as __typename
+ */
+func FieldsOnCorrectTypeRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Field)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					ttype := context.GetParentType()
+					if ttype != nil && context.GetFieldDef() == nil {
+						fieldName := ""
+						if node.Name != nil {
+							fieldName = node.Name.Value
+						}
+						reportError(
+							context,
+							fmt.Sprintf(`Cannot query field "%v" on "%v".`, fieldName, ttype.GetName()),
+							[]ast.Node{node},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Fragments on composite type
+ *
+ * Fragments use a type condition to determine if they apply, since fragments
+ * can only be spread into a composite type (object, interface, or union), the
+ * type condition must also be a composite type.
+ */
+func FragmentsOnCompositeTypesRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.InlineFragment: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.InlineFragment)
+					if !ok || node.TypeCondition == nil {
+						return visitor.ActionNoChange, nil
+					}
+					ttype := context.GetType()
+					if ttype != nil && !isCompositeType(ttype) {
+						reportError(
+							context,
+							fmt.Sprintf(`Fragment cannot condition on non composite type "%v".`,
+								printer.Print(node.TypeCondition)),
+							[]ast.Node{node.TypeCondition},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.FragmentDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.FragmentDefinition)
+					if !ok || node.TypeCondition == nil {
+						return visitor.ActionNoChange, nil
+					}
+					ttype := context.GetType()
+					if ttype != nil && !isCompositeType(ttype) {
+						fragName := ""
+						if node.Name != nil {
+							fragName = node.Name.Value
+						}
+						reportError(
+							context,
+							fmt.Sprintf(`Fragment "%v" cannot condition on non composite type "%v".`,
+								fragName, printer.Print(node.TypeCondition)),
+							[]ast.Node{node.TypeCondition},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Known argument names
+ *
+ * A GraphQL field is only valid if all supplied arguments are defined by
+ * that field.
+ */
+func KnownArgumentNamesRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Argument: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Argument)
+					if !ok || len(p.Ancestors) == 0 {
+						return visitor.ActionNoChange, nil
+					}
+					argName := ""
+					if node.Name != nil {
+						argName = node.Name.Value
+					}
+					switch p.Ancestors[len(p.Ancestors)-1].(type) {
+					case *ast.Field:
+						fieldDef := context.GetFieldDef()
+						if fieldDef == nil || findArgument(fieldDef.Args, argName) != nil {
+							return visitor.ActionNoChange, nil
+						}
+						parentTypeName := ""
+						if parentType := context.GetParentType(); parentType != nil {
+							parentTypeName = parentType.GetName()
+						}
+						reportError(
+							context,
+							fmt.Sprintf(`Unknown argument "%v" on field "%v" of type "%v".`,
+								argName, fieldDef.Name, parentTypeName),
+							[]ast.Node{node},
+						)
+					case *ast.Directive:
+						directive := context.GetDirective()
+						if directive == nil || findArgument(directive.Args, argName) != nil {
+							return visitor.ActionNoChange, nil
+						}
+						reportError(
+							context,
+							fmt.Sprintf(`Unknown argument "%v" on directive "@%v".`, argName, directive.Name),
+							[]ast.Node{node},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Known directives
+ *
+ * A GraphQL document is only valid if all `@directives` are known by the
+ * schema and legally positioned.
+ */
+func KnownDirectivesRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Directive: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Directive)
+					if !ok || node.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					directiveName := node.Name.Value
+					directiveDef := context.GetSchema().GetDirective(directiveName)
+					if directiveDef == nil {
+						return reportError(
+							context,
+							fmt.Sprintf(`Unknown directive "%v".`, directiveName),
+							[]ast.Node{node},
+						)
+					}
+					if len(p.Ancestors) == 0 {
+						return visitor.ActionNoChange, nil
+					}
+					location := getDirectiveLocation(p.Ancestors[len(p.Ancestors)-1])
+					if location != "" && !directiveDef.AllowsLocation(location) {
+						reportError(
+							context,
+							fmt.Sprintf(`Directive "%v" may not be used on %v.`, directiveName, location),
+							[]ast.Node{node},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+// getDirectiveLocation returns the location of the directives of the node,
+// which is empty for the nodes which do not take directives.
+func getDirectiveLocation(node interface{}) DirectiveLocation {
+	switch node := node.(type) {
+	case *ast.OperationDefinition:
+		switch node.Operation {
+		case "query":
+			return DirectiveLocationQuery
+		case "mutation":
+			return DirectiveLocationMutation
+		case "subscription":
+			return DirectiveLocationSubscription
+		}
+	case *ast.Field:
+		return DirectiveLocationField
+	case *ast.FragmentSpread:
+		return DirectiveLocationFragmentSpread
+	case *ast.InlineFragment:
+		return DirectiveLocationInlineFragment
+	case *ast.FragmentDefinition:
+		return DirectiveLocationFragmentDefinition
+	}
+	return ""
+}
+
+/**
+ * Known fragment names
+ *
+ * A GraphQL document is only valid if all `...Fragment` fragment spreads refer
+ * to fragments defined in the same document.
+ */
+func KnownFragmentNamesRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.FragmentSpread: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.FragmentSpread)
+					if !ok || node.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					fragmentName := node.Name.Value
+					if context.GetFragment(fragmentName) == nil {
+						reportError(
+							context,
+							fmt.Sprintf(`Unknown fragment "%v".`, fragmentName),
+							[]ast.Node{node.Name},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Known type names
+ *
+ * A GraphQL document is only valid if referenced types (specifically
+ * variable definitions and fragment conditions) are defined by the type schema.
+ */
+func KnownTypeNamesRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Named: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Named)
+					if !ok || node.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					typeName := node.Name.Value
+					if context.GetSchema().GetType(typeName) == nil {
+						reportError(
+							context,
+							fmt.Sprintf(`Unknown type "%v".`, typeName),
+							[]ast.Node{node},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Lone anonymous operation
+ *
+ * A GraphQL document is only valid if when it contains an anonymous operation
+ * (the query short-hand) that it contains only that one operation definition.
+ */
+func LoneAnonymousOperationRule(context *ValidationContext) *visitor.VisitorOptions {
+	operationCount := 0
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Document: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Document)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					operationCount = 0
+					for _, definition := range node.Definitions {
+						if definition.GetKind() == kinds.OperationDefinition {
+							operationCount++
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.OperationDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.OperationDefinition)
+					if ok && node.Name == nil && operationCount > 1 {
+						reportError(
+							context,
+							`This anonymous operation must be the only defined operation.`,
+							[]ast.Node{node},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Single field subscriptions
+ *
+ * A GraphQL subscription is valid only if it contains a single root field,
+ * which provides the source event stream of the subscription.
+ */
+func SingleFieldSubscriptionsRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.OperationDefinition)
+					if !ok || node.Operation != "subscription" || node.SelectionSet == nil {
+						return visitor.ActionNoChange, nil
+					}
+					if len(node.SelectionSet.Selections) != 1 {
+						name := ""
+						if node.Name != nil {
+							name = node.Name.Value
+						}
+						nodes := []ast.Node{}
+						for _, selection := range node.SelectionSet.Selections[1:] {
+							if selection, ok := selection.(ast.Node); ok {
+								nodes = append(nodes, selection)
+							}
+						}
+						reportError(
+							context,
+							fmt.Sprintf(`Subscription "%v" must select only one top level field.`, name),
+							nodes,
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * No fragment cycles
+ *
+ * A GraphQL document is only valid if fragment spreads do not form cycles,
+ * directly or through other fragments.
+ */
+func NoFragmentCyclesRule(context *ValidationContext) *visitor.VisitorOptions {
+	// Tracks already visited fragments to maintain O(N) and to ensure that
+	// cycles are not redundantly reported.
+	visitedFrags := map[string]bool{}
+
+	// Array of AST nodes used to produce meaningful errors
+	spreadPath := []*ast.FragmentSpread{}
+
+	// Position in the spread path
+	spreadPathIndexByName := map[string]int{}
+
+	// This does a straight-forward DFS to find cycles.
+	// It does not terminate when a cycle was found but continues to explore
+	// the graph to find all possible cycles.
+	var detectCycleRecursive func(fragment *ast.FragmentDefinition)
+	detectCycleRecursive = func(fragment *ast.FragmentDefinition) {
+		fragmentName := fragment.Name.Value
+		visitedFrags[fragmentName] = true
+
+		spreadNodes := context.GetFragmentSpreads(fragment.SelectionSet)
+		if len(spreadNodes) == 0 {
+			return
+		}
+
+		spreadPathIndexByName[fragmentName] = len(spreadPath)
+
+		for _, spreadNode := range spreadNodes {
+			if spreadNode.Name == nil {
+				continue
+			}
+			spreadName := spreadNode.Name.Value
+			cycleIndex, ok := spreadPathIndexByName[spreadName]
+			if !ok {
+				spreadPath = append(spreadPath, spreadNode)
+				if !visitedFrags[spreadName] {
+					spreadFragment := context.GetFragment(spreadName)
+					if spreadFragment != nil {
+						detectCycleRecursive(spreadFragment)
+					}
+				}
+				spreadPath = spreadPath[:len(spreadPath)-1]
+				continue
+			}
+
+			cyclePath := spreadPath[cycleIndex:]
+			spreadNames := []string{}
+			nodes := []ast.Node{}
+			for _, s := range cyclePath {
+				spreadNames = append(spreadNames, s.Name.Value)
+				nodes = append(nodes, s)
+			}
+			nodes = append(nodes, spreadNode)
+			via := "."
+			if len(spreadNames) > 0 {
+				via = " via " + strings.Join(spreadNames, ", ") + "."
+			}
+			reportError(
+				context,
+				fmt.Sprintf(`Cannot spread fragment "%v" within itself%v`, spreadName, via),
+				nodes,
+			)
+		}
+		delete(spreadPathIndexByName, fragmentName)
+	}
+
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.FragmentDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.FragmentDefinition)
+					if ok && node.Name != nil && !visitedFrags[node.Name.Value] {
+						detectCycleRecursive(node)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * No undefined variables
+ *
+ * A GraphQL operation is only valid if all variables encountered, both directly
+ * and via fragment spreads, are defined by that operation.
+ */
+func NoUndefinedVariablesRule(context *ValidationContext) *visitor.VisitorOptions {
+	variableNameDefined := map[string]bool{}
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: visitor.NamedVisitFuncs{
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					variableNameDefined = map[string]bool{}
+					return visitor.ActionNoChange, nil
+				},
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					operation, ok := p.Node.(*ast.OperationDefinition)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					for _, usage := range context.GetRecursiveVariableUsages(operation) {
+						if usage.Node == nil || usage.Node.Name == nil {
+							continue
+						}
+						varName := usage.Node.Name.Value
+						if variableNameDefined[varName] {
+							continue
+						}
+						message := fmt.Sprintf(`Variable "$%v" is not defined.`, varName)
+						if operation.Name != nil && operation.Name.Value != "" {
+							message = fmt.Sprintf(`Variable "$%v" is not defined by operation "%v".`,
+								varName, operation.Name.Value)
+						}
+						reportError(context, message, []ast.Node{usage.Node, operation})
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.VariableDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.VariableDefinition)
+					if ok && node.Variable != nil && node.Variable.Name != nil {
+						variableNameDefined[node.Variable.Name.Value] = true
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * No unused fragments
+ *
+ * A GraphQL document is only valid if all fragment definitions are spread
+ * within operations, or spread within other fragments spread within operations.
+ */
+func NoUnusedFragmentsRule(context *ValidationContext) *visitor.VisitorOptions {
+	operationDefs := []*ast.OperationDefinition{}
+	fragmentDefs := []*ast.FragmentDefinition{}
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.OperationDefinition); ok {
+						operationDefs = append(operationDefs, node)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.FragmentDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.FragmentDefinition); ok {
+						fragmentDefs = append(fragmentDefs, node)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.Document: visitor.NamedVisitFuncs{
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					fragmentNameUsed := map[string]bool{}
+					for _, operation := range operationDefs {
+						for _, fragment := range context.GetRecursivelyReferencedFragments(operation) {
+							fragmentNameUsed[fragment.Name.Value] = true
+						}
+					}
+					for _, fragmentDef := range fragmentDefs {
+						if fragmentDef.Name == nil || fragmentNameUsed[fragmentDef.Name.Value] {
+							continue
+						}
+						reportError(
+							context,
+							fmt.Sprintf(`Fragment "%v" is never used.`, fragmentDef.Name.Value),
+							[]ast.Node{fragmentDef},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * No unused variables
+ *
+ * A GraphQL operation is only valid if all variables defined by an operation
+ * are used, either directly or within a spread fragment.
+ */
+func NoUnusedVariablesRule(context *ValidationContext) *visitor.VisitorOptions {
+	variableDefs := []*ast.VariableDefinition{}
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: visitor.NamedVisitFuncs{
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					variableDefs = []*ast.VariableDefinition{}
+					return visitor.ActionNoChange, nil
+				},
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					operation, ok := p.Node.(*ast.OperationDefinition)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					variableNameUsed := map[string]bool{}
+					for _, usage := range context.GetRecursiveVariableUsages(operation) {
+						if usage.Node != nil && usage.Node.Name != nil {
+							variableNameUsed[usage.Node.Name.Value] = true
+						}
+					}
+					for _, variableDef := range variableDefs {
+						if variableDef.Variable == nil || variableDef.Variable.Name == nil {
+							continue
+						}
+						variableName := variableDef.Variable.Name.Value
+						if variableNameUsed[variableName] {
+							continue
+						}
+						message := fmt.Sprintf(`Variable "$%v" is never used.`, variableName)
+						if operation.Name != nil && operation.Name.Value != "" {
+							message = fmt.Sprintf(`Variable "$%v" is never used in operation "%v".`,
+								variableName, operation.Name.Value)
+						}
+						reportError(context, message, []ast.Node{variableDef})
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.VariableDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.VariableDefinition); ok {
+						variableDefs = append(variableDefs, node)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Overlapping fields can be merged
+ *
+ * A selection set is only valid if all fields (including spreading any
+ * fragments) either correspond to distinct response names or can be merged
+ * without ambiguity.
+ */
+func OverlappingFieldsCanBeMergedRule(context *ValidationContext) *visitor.VisitorOptions {
+	comparedSet := newPairSet()
+	var findConflicts func(parentFieldsAreMutuallyExclusive bool, fieldMap *astAndDefCollection) []*conflict
+	var findConflict func(parentFieldsAreMutuallyExclusive bool, responseName string, field1 *fieldDefPair, field2 *fieldDefPair) *conflict
+
+	findConflicts = func(parentFieldsAreMutuallyExclusive bool, fieldMap *astAndDefCollection) []*conflict {
+		conflicts := []*conflict{}
+		for _, responseName := range fieldMap.order {
+			fields := fieldMap.fields[responseName]
+			if len(fields) <= 1 {
+				continue
+			}
+			for i := 0; i < len(fields); i++ {
+				for j := i; j < len(fields); j++ {
+					c := findConflict(parentFieldsAreMutuallyExclusive, responseName, fields[i], fields[j])
+					if c != nil {
+						conflicts = append(conflicts, c)
+					}
+				}
+			}
+		}
+		return conflicts
+	}
+
+	findConflict = func(parentFieldsAreMutuallyExclusive bool, responseName string, field1 *fieldDefPair, field2 *fieldDefPair) *conflict {
+		parentType1, ast1, def1 := field1.ParentType, field1.Field, field1.FieldDef
+		parentType2, ast2, def2 := field2.ParentType, field2.Field, field2.FieldDef
+
+		// Not a pair.
+		if ast1 == ast2 {
+			return nil
+		}
+		// Memoize, do not report the same issue twice.
+		if comparedSet.Has(ast1, ast2) {
+			return nil
+		}
+		comparedSet.Add(ast1, ast2)
+
+		// The return type for each field.
+		var type1, type2 Type
+		if def1 != nil {
+			type1 = def1.Type
+		}
+		if def2 != nil {
+			type2 = def2.Type
+		}
+
+		// If it is known that two fields could not possibly apply at the same
+		// time, due to the parent types, then it is safe to permit them to
+		// diverge as long as the shape of the response remains consistent.
+		_, isObject1 := parentType1.(*Object)
+		_, isObject2 := parentType2.(*Object)
+		areMutuallyExclusive := parentFieldsAreMutuallyExclusive ||
+			(parentType1 != parentType2 && isObject1 && isObject2)
+
+		if !areMutuallyExclusive {
+			name1, name2 := "", ""
+			if ast1.Name != nil {
+				name1 = ast1.Name.Value
+			}
+			if ast2.Name != nil {
+				name2 = ast2.Name.Value
+			}
+			if name1 != name2 {
+				return &conflict{
+					Reason:      conflictReason{Name: responseName, Message: fmt.Sprintf(`%v and %v are different fields`, name1, name2)},
+					FieldsLeft:  []ast.Node{ast1},
+					FieldsRight: []ast.Node{ast2},
+				}
+			}
+			if !sameArguments(ast1.Arguments, ast2.Arguments) {
+				return &conflict{
+					Reason:      conflictReason{Name: responseName, Message: `they have differing arguments`},
+					FieldsLeft:  []ast.Node{ast1},
+					FieldsRight: []ast.Node{ast2},
+				}
+			}
+		}
+
+		if type1 != nil && type2 != nil && doTypesConflict(type1, type2) {
+			return &conflict{
+				Reason:      conflictReason{Name: responseName, Message: fmt.Sprintf(`they return conflicting types %v and %v`, type1, type2)},
+				FieldsLeft:  []ast.Node{ast1},
+				FieldsRight: []ast.Node{ast2},
+			}
+		}
+
+		selectionSet1 := ast1.SelectionSet
+		selectionSet2 := ast2.SelectionSet
+		if selectionSet1 != nil && selectionSet2 != nil {
+			visitedFragmentNames := map[string]bool{}
+			subfieldMap := collectFieldASTsAndDefs(context, GetNamed(type1), selectionSet1, visitedFragmentNames, nil)
+			subfieldMap = collectFieldASTsAndDefs(context, GetNamed(type2), selectionSet2, visitedFragmentNames, subfieldMap)
+			conflicts := findConflicts(areMutuallyExclusive, subfieldMap)
+			if len(conflicts) > 0 {
+				conflictReasons := []conflictReason{}
+				conflictFieldsLeft := []ast.Node{ast1}
+				conflictFieldsRight := []ast.Node{ast2}
+				for _, c := range conflicts {
+					conflictReasons = append(conflictReasons, c.Reason)
+					conflictFieldsLeft = append(conflictFieldsLeft, c.FieldsLeft...)
+					conflictFieldsRight = append(conflictFieldsRight, c.FieldsRight...)
+				}
+				return &conflict{
+					Reason:      conflictReason{Name: responseName, Message: conflictReasons},
+					FieldsLeft:  conflictFieldsLeft,
+					FieldsRight: conflictFieldsRight,
+				}
+			}
+		}
+		return nil
+	}
+
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.SelectionSet: visitor.NamedVisitFuncs{
+				// Note: we validate on the reverse traversal so deeper conflicts will be
+				// caught first, for clearer error messages.
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					selectionSet, ok := p.Node.(*ast.SelectionSet)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					parentType, _ := context.GetParentType().(Named)
+					fieldMap := collectFieldASTsAndDefs(context, parentType, selectionSet, nil, nil)
+					for _, c := range findConflicts(false, fieldMap) {
+						reportError(
+							context,
+							fmt.Sprintf(`Fields "%v" conflict because %v.`, c.Reason.Name, conflictReasonMessage(c.Reason.Message)),
+							append(c.FieldsLeft, c.FieldsRight...),
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+type fieldDefPair struct {
+	ParentType Named
+	Field      *ast.Field
+	FieldDef   *FieldDefinition
+}
+
+// astAndDefCollection keeps track of the order in which response names were
+// first encountered, so that conflicts are reported deterministically.
+type astAndDefCollection struct {
+	order  []string
+	fields map[string][]*fieldDefPair
+}
+
+type conflictReason struct {
+	Name    string
+	Message interface{} // conflictReason.Message can be a string or []conflictReason
+}
+
+type conflict struct {
+	Reason      conflictReason
+	FieldsLeft  []ast.Node
+	FieldsRight []ast.Node
+}
+
+func conflictReasonMessage(message interface{}) string {
+	switch reason := message.(type) {
+	case string:
+		return reason
+	case []conflictReason:
+		messages := []string{}
+		for _, r := range reason {
+			messages = append(messages, fmt.Sprintf(`subfields "%v" conflict because %v`, r.Name, conflictReasonMessage(r.Message)))
+		}
+		return strings.Join(messages, " and ")
+	}
+	return ""
+}
+
+/**
+ * Given a selectionSet, adds all of the fields in that selection to
+ * the passed in map of fields, and returns it at the end.
+ *
+ * Note: This is not the same as execution's collectFields because at static
+ * time we do not know what object type will be used, so we unconditionally
+ * spread in all fragments.
+ */
+func collectFieldASTsAndDefs(context *ValidationContext, parentType Named, selectionSet *ast.SelectionSet, visitedFragmentNames map[string]bool, astAndDefs *astAndDefCollection) *astAndDefCollection {
+	if astAndDefs == nil {
+		astAndDefs = &astAndDefCollection{
+			fields: map[string][]*fieldDefPair{},
+		}
+	}
+	if visitedFragmentNames == nil {
+		visitedFragmentNames = map[string]bool{}
+	}
+	if selectionSet == nil {
+		return astAndDefs
+	}
+	schema := context.GetSchema()
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			fieldName := ""
+			if selection.Name != nil {
+				fieldName = selection.Name.Value
+			}
+			var fieldDef *FieldDefinition
+			switch parentType := parentType.(type) {
+			case *Object:
+				fieldDef = parentType.GetFields()[fieldName]
+			case *Interface:
+				fieldDef = parentType.GetFields()[fieldName]
+			}
+			responseName := fieldName
+			if selection.Alias != nil {
+				responseName = selection.Alias.Value
+			}
+			if _, ok := astAndDefs.fields[responseName]; !ok {
+				astAndDefs.order = append(astAndDefs.order, responseName)
+			}
+			astAndDefs.fields[responseName] = append(astAndDefs.fields[responseName], &fieldDefPair{
+				ParentType: parentType,
+				Field:      selection,
+				FieldDef:   fieldDef,
+			})
+		case *ast.InlineFragment:
+			inlineFragmentType := parentType
+			if selection.TypeCondition != nil {
+				inlineFragmentType = outputTypeFromAST(schema, selection.TypeCondition)
+			}
+			astAndDefs = collectFieldASTsAndDefs(context, inlineFragmentType, selection.SelectionSet, visitedFragmentNames, astAndDefs)
+		case *ast.FragmentSpread:
+			if selection.Name == nil {
+				continue
+			}
+			fragName := selection.Name.Value
+			if visitedFragmentNames[fragName] {
+				continue
+			}
+			visitedFragmentNames[fragName] = true
+			fragment := context.GetFragment(fragName)
+			if fragment == nil {
+				continue
+			}
+			fragmentType := outputTypeFromAST(schema, fragment.TypeCondition)
+			astAndDefs = collectFieldASTsAndDefs(context, fragmentType, fragment.SelectionSet, visitedFragmentNames, astAndDefs)
+		}
+	}
+	return astAndDefs
+}
+
+func sameArguments(args1 []*ast.Argument, args2 []*ast.Argument) bool {
+	if len(args1) != len(args2) {
+		return false
+	}
+	for _, arg1 := range args1 {
+		var arg2 *ast.Argument
+		for _, arg := range args2 {
+			if arg.Name != nil && arg1.Name != nil && arg.Name.Value == arg1.Name.Value {
+				arg2 = arg
+				break
+			}
+		}
+		if arg2 == nil {
+			return false
+		}
+		if !sameValue(arg1.Value, arg2.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameValue(value1 ast.Value, value2 ast.Value) bool {
+	if value1 == nil && value2 == nil {
+		return true
+	}
+	if value1 == nil || value2 == nil {
+		return false
+	}
+	return printer.Print(value1) == printer.Print(value2)
+}
+
+// Two types conflict if both types could not apply to a value simultaneously.
+// Composite types are ignored as their individual field types will be compared
+// later recursively. However List and Non-Null types must match.
+func doTypesConflict(type1 Type, type2 Type) bool {
+	if type1, ok := type1.(*List); ok {
+		if type2, ok := type2.(*List); ok {
+			return doTypesConflict(type1.OfType, type2.OfType)
+		}
+		return true
+	}
+	if type2, ok := type2.(*List); ok {
+		if type1, ok := type1.(*List); ok {
+			return doTypesConflict(type1.OfType, type2.OfType)
+		}
+		return true
+	}
+	if type1, ok := type1.(*NonNull); ok {
+		if type2, ok := type2.(*NonNull); ok {
+			return doTypesConflict(type1.OfType, type2.OfType)
+		}
+		return true
+	}
+	if type2, ok := type2.(*NonNull); ok {
+		if type1, ok := type1.(*NonNull); ok {
+			return doTypesConflict(type1.OfType, type2.OfType)
+		}
+		return true
+	}
+	if isLeafType(type1) || isLeafType(type2) {
+		return type1 != type2
+	}
+	return false
+}
+
+/**
+ * A way to keep track of pairs of things when the ordering of the pair does
+ * not matter. We do this by maintaining a sort of double adjacency sets.
+ */
+type pairSet struct {
+	data map[*ast.Field]map[*ast.Field]bool
+}
+
+func newPairSet() *pairSet {
+	return &pairSet{
+		data: map[*ast.Field]map[*ast.Field]bool{},
+	}
+}
+func (pair *pairSet) Has(a *ast.Field, b *ast.Field) bool {
+	first, ok := pair.data[a]
+	if !ok || first == nil {
+		return false
+	}
+	return first[b]
+}
+func (pair *pairSet) Add(a *ast.Field, b *ast.Field) {
+	pairSetAdd(pair.data, a, b)
+	pairSetAdd(pair.data, b, a)
+}
+func pairSetAdd(data map[*ast.Field]map[*ast.Field]bool, a *ast.Field, b *ast.Field) {
+	set, ok := data[a]
+	if !ok || set == nil {
+		set = map[*ast.Field]bool{}
+		data[a] = set
+	}
+	set[b] = true
+}
+
+/**
+ * Possible fragment spread
+ *
+ * A fragment spread is only valid if the type condition could ever possibly
+ * be true: if there is a non-empty intersection of the possible parent types,
+ * and possible types which pass the type condition.
+ */
+func PossibleFragmentSpreadsRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.InlineFragment: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.InlineFragment)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					fragType := context.GetType()
+					parentType, _ := context.GetParentType().(Type)
+					if fragType != nil && parentType != nil && !doTypesOverlap(fragType, parentType) {
+						reportError(
+							context,
+							fmt.Sprintf(`Fragment cannot be spread here as objects of `+
+								`type "%v" can never be of type "%v".`, parentType, fragType),
+							[]ast.Node{node},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.FragmentSpread: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.FragmentSpread)
+					if !ok || node.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					fragName := node.Name.Value
+					var fragType Type
+					if fragment := context.GetFragment(fragName); fragment != nil {
+						if ttype := outputTypeFromAST(context.GetSchema(), fragment.TypeCondition); ttype != nil {
+							fragType = ttype
+						}
+					}
+					parentType, _ := context.GetParentType().(Type)
+					if fragType != nil && parentType != nil && !doTypesOverlap(fragType, parentType) {
+						reportError(
+							context,
+							fmt.Sprintf(`Fragment "%v" cannot be spread here as objects of `+
+								`type "%v" can never be of type "%v".`, fragName, parentType, fragType),
+							[]ast.Node{node},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Provided required arguments
+ *
+ * A field or directive is only valid if all required (non-null) field arguments
+ * have been provided.
+ */
+func ProvidedNonNullArgumentsRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: visitor.NamedVisitFuncs{
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					// Validate on leave to allow for deeper errors to appear first.
+					fieldAST, ok := p.Node.(*ast.Field)
+					fieldDef := context.GetFieldDef()
+					if !ok || fieldDef == nil {
+						return visitor.ActionSkip, nil
+					}
+					argASTMap := map[string]*ast.Argument{}
+					for _, argAST := range fieldAST.Arguments {
+						if argAST.Name != nil {
+							argASTMap[argAST.Name.Value] = argAST
+						}
+					}
+					for _, argDef := range fieldDef.Args {
+						_, isNonNull := argDef.Type.(*NonNull)
+						if _, ok := argASTMap[argDef.Name]; !ok && isNonNull {
+							reportError(
+								context,
+								fmt.Sprintf(`Field "%v" argument "%v" of type "%v" is required but not provided.`,
+									fieldAST.Name.Value, argDef.Name, argDef.Type),
+								[]ast.Node{fieldAST},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.Directive: visitor.NamedVisitFuncs{
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					// Validate on leave to allow for deeper errors to appear first.
+					directiveAST, ok := p.Node.(*ast.Directive)
+					directiveDef := context.GetDirective()
+					if !ok || directiveDef == nil {
+						return visitor.ActionSkip, nil
+					}
+					argASTMap := map[string]*ast.Argument{}
+					for _, argAST := range directiveAST.Arguments {
+						if argAST.Name != nil {
+							argASTMap[argAST.Name.Value] = argAST
+						}
+					}
+					for _, argDef := range directiveDef.Args {
+						_, isNonNull := argDef.Type.(*NonNull)
+						if _, ok := argASTMap[argDef.Name]; !ok && isNonNull {
+							reportError(
+								context,
+								fmt.Sprintf(`Directive "@%v" argument "%v" of type "%v" is required but not provided.`,
+									directiveAST.Name.Value, argDef.Name, argDef.Type),
+								[]ast.Node{directiveAST},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Scalar leafs
+ *
+ * A GraphQL document is valid only if all leaf fields (fields without
+ * sub selections) are of scalar or enum types.
+ */
+func ScalarLeafsRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Field)
+					ttype := context.GetType()
+					if !ok || ttype == nil {
+						return visitor.ActionNoChange, nil
+					}
+					fieldName := ""
+					if node.Name != nil {
+						fieldName = node.Name.Value
+					}
+					if isLeafType(ttype) {
+						if node.SelectionSet != nil {
+							reportError(
+								context,
+								fmt.Sprintf(`Field "%v" of type "%v" must not have a sub selection.`, fieldName, ttype),
+								[]ast.Node{node.SelectionSet},
+							)
+						}
+					} else if node.SelectionSet == nil {
+						reportError(
+							context,
+							fmt.Sprintf(`Field "%v" of type "%v" must have a sub selection.`, fieldName, ttype),
+							[]ast.Node{node},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Unique argument names
+ *
+ * A GraphQL field or directive is only valid if all supplied arguments are
+ * uniquely named.
+ */
+func UniqueArgumentNamesRule(context *ValidationContext) *visitor.VisitorOptions {
+	knownArgNames := map[string]*ast.Name{}
+	resetKnownArgNames := func(p visitor.VisitFuncParams) (string, interface{}) {
+		knownArgNames = map[string]*ast.Name{}
+		return visitor.ActionNoChange, nil
+	}
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: visitor.NamedVisitFuncs{
+				Kind: resetKnownArgNames,
+			},
+			kinds.Directive: visitor.NamedVisitFuncs{
+				Kind: resetKnownArgNames,
+			},
+			kinds.Argument: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Argument)
+					if !ok || node.Name == nil {
+						return visitor.ActionSkip, nil
+					}
+					argName := node.Name.Value
+					if nameAST, ok := knownArgNames[argName]; ok {
+						reportError(
+							context,
+							fmt.Sprintf(`There can be only one argument named "%v".`, argName),
+							[]ast.Node{nameAST, node.Name},
+						)
+					} else {
+						knownArgNames[argName] = node.Name
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Unique fragment names
+ *
+ * A GraphQL document is only valid if all defined fragments have unique names.
+ */
+func UniqueFragmentNamesRule(context *ValidationContext) *visitor.VisitorOptions {
+	knownFragmentNames := map[string]*ast.Name{}
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.FragmentDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.FragmentDefinition)
+					if !ok || node.Name == nil {
+						return visitor.ActionSkip, nil
+					}
+					fragmentName := node.Name.Value
+					if nameAST, ok := knownFragmentNames[fragmentName]; ok {
+						reportError(
+							context,
+							fmt.Sprintf(`There can only be one fragment named "%v".`, fragmentName),
+							[]ast.Node{nameAST, node.Name},
+						)
+					} else {
+						knownFragmentNames[fragmentName] = node.Name
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Unique input field names
+ *
+ * A GraphQL input object value is only valid if all supplied fields are
+ * uniquely named.
+ */
+func UniqueInputFieldNamesRule(context *ValidationContext) *visitor.VisitorOptions {
+	knownNameStack := []map[string]*ast.Name{}
+	knownNames := map[string]*ast.Name{}
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.ObjectValue: visitor.NamedVisitFuncs{
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					knownNameStack = append(knownNameStack, knownNames)
+					knownNames = map[string]*ast.Name{}
+					return visitor.ActionNoChange, nil
+				},
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					knownNames = knownNameStack[len(knownNameStack)-1]
+					knownNameStack = knownNameStack[:len(knownNameStack)-1]
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.ObjectField: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.ObjectField)
+					if !ok || node.Name == nil {
+						return visitor.ActionSkip, nil
+					}
+					fieldName := node.Name.Value
+					if nameAST, ok := knownNames[fieldName]; ok {
+						reportError(
+							context,
+							fmt.Sprintf(`There can be only one input field named "%v".`, fieldName),
+							[]ast.Node{nameAST, node.Name},
+						)
+					} else {
+						knownNames[fieldName] = node.Name
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Unique operation names
+ *
+ * A GraphQL document is only valid if all defined operations have unique names.
+ */
+func UniqueOperationNamesRule(context *ValidationContext) *visitor.VisitorOptions {
+	knownOperationNames := map[string]*ast.Name{}
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.OperationDefinition)
+					if !ok || node.Name == nil {
+						return visitor.ActionSkip, nil
+					}
+					operationName := node.Name.Value
+					if nameAST, ok := knownOperationNames[operationName]; ok {
+						reportError(
+							context,
+							fmt.Sprintf(`There can only be one operation named "%v".`, operationName),
+							[]ast.Node{nameAST, node.Name},
+						)
+					} else {
+						knownOperationNames[operationName] = node.Name
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.FragmentDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Variables are input types
+ *
+ * A GraphQL operation is only valid if all the variables it defines are of
+ * input types (scalar, enum, or input object).
+ */
+func VariablesAreInputTypesRule(context *ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.VariableDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.VariableDefinition)
+					if !ok || node.Type == nil {
+						return visitor.ActionNoChange, nil
+					}
+					ttype, _ := typeFromAST(*context.GetSchema(), node.Type)
+					// If the variable type is not an input type, return an error.
+					if ttype != nil && GetNamed(ttype) != nil && !IsInputType(ttype) {
+						variableName := ""
+						if node.Variable != nil && node.Variable.Name != nil {
+							variableName = node.Variable.Name.Value
+						}
+						reportError(
+							context,
+							fmt.Sprintf(`Variable "$%v" cannot be non-input type "%v".`,
+								variableName, printer.Print(node.Type)),
+							[]ast.Node{node.Type},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+/**
+ * Variables passed to field arguments conform to type
+ */
+func VariablesInAllowedPositionRule(context *ValidationContext) *visitor.VisitorOptions {
+	varDefMap := map[string]*ast.VariableDefinition{}
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: visitor.NamedVisitFuncs{
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					varDefMap = map[string]*ast.VariableDefinition{}
+					return visitor.ActionNoChange, nil
+				},
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					operation, ok := p.Node.(*ast.OperationDefinition)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					for _, usage := range context.GetRecursiveVariableUsages(operation) {
+						if usage.Node == nil || usage.Node.Name == nil || usage.Type == nil {
+							continue
+						}
+						varName := usage.Node.Name.Value
+						varDef, ok := varDefMap[varName]
+						if !ok {
+							continue
+						}
+						// A var type is allowed if it is the same or more strict (e.g. is
+						// a subtype of) than the expected type. It can be more strict if
+						// the variable type is non-null when the expected type is nullable.
+						// If both are list types, the variable item type can be more strict
+						// than the expected item type (contravariant).
+						varType, _ := typeFromAST(*context.GetSchema(), varDef.Type)
+						if varType == nil || GetNamed(varType) == nil {
+							continue
+						}
+						if !isTypeSubTypeOf(effectiveType(varType, varDef), usage.Type) {
+							reportError(
+								context,
+								fmt.Sprintf(`Variable "$%v" of type "%v" used in position expecting type "%v".`,
+									varName, varType, usage.Type),
+								[]ast.Node{varDef, usage.Node},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.VariableDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.VariableDefinition)
+					if ok && node.Variable != nil && node.Variable.Name != nil {
+						varDefMap[node.Variable.Name.Value] = node
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+// If a variable definition has a default value, it's effectively non-null.
+func effectiveType(varType Type, varDef *ast.VariableDefinition) Type {
+	if varDef.DefaultValue == nil {
+		return varType
+	}
+	if _, ok := varType.(*NonNull); ok {
+		return varType
+	}
+	return NewNonNull(varType)
+}
+
+func findArgument(args []*Argument, name string) *Argument {
+	for _, arg := range args {
+		if arg.Name == name {
+			return arg
+		}
+	}
+	return nil
+}
+
+func isCompositeType(ttype Type) bool {
+	switch ttype.(type) {
+	case *Object, *Interface, *Union:
+		return true
+	}
+	return false
+}
+
+func isLeafType(ttype Type) bool {
+	switch GetNamed(ttype).(type) {
+	case *Scalar, *Enum:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,626 @@
+package graphql_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_UniqueOperationNames_MultipleOperationsOfSameName(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query Foo {
+        dog { name }
+      }
+      query Foo {
+        cat { name }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can only be one operation named "Foo".`, 2, 13, 5, 13),
+	})
+}
+
+func TestValidate_LoneAnonymousOperation_MultipleAnonOperations(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog { name }
+      }
+      {
+        cat { name }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`This anonymous operation must be the only defined operation.`, 2, 7),
+		testutil.RuleError(`This anonymous operation must be the only defined operation.`, 5, 7),
+	})
+}
+
+func TestValidate_SingleFieldSubscriptions_ValidSubscription(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        importantEmails
+      }
+    `)
+}
+
+func TestValidate_SingleFieldSubscriptions_FailsWithMoreThanOneRootField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        importantEmails
+        notImportantEmails
+        spamEmails
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Subscription "ImportantEmails" must select only one top level field.`, 4, 9, 5, 9),
+	})
+}
+
+func TestValidate_KnownTypeNames_UnknownTypeNamesAreInvalid(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query Foo($var: JumbledUpLetters) {
+        dog { name, ...PetFields }
+      }
+      fragment PetFields on Peettt {
+        name
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Unknown type "JumbledUpLetters".`, 2, 23),
+		testutil.RuleError(`Variable "$var" is never used in operation "Foo".`, 2, 17),
+		testutil.RuleError(`Unknown type "Peettt".`, 5, 29),
+	})
+}
+
+func TestValidate_FragmentsOnCompositeTypes_ScalarIsInvalidFragmentType(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog { ...scalarFragment }
+      }
+      fragment scalarFragment on Boolean {
+        bad
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fragment "scalarFragment" cannot be spread here as objects of type "Dog" can never be of type "Boolean".`, 3, 15),
+		testutil.RuleError(`Fragment "scalarFragment" cannot condition on non composite type "Boolean".`, 5, 34),
+	})
+}
+
+func TestValidate_VariablesAreInputTypes_OutputTypesAreInvalid(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query Foo($a: Dog, $b: [[CatOrDog!]]!) {
+        dog { name }
+        complicatedArgs {
+          a: complexArgField(complexArg: $a)
+          b: stringListArgField(stringListArg: $b)
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$a" cannot be non-input type "Dog".`, 2, 21),
+		testutil.RuleError(`Variable "$b" cannot be non-input type "[[CatOrDog!]]!".`, 2, 30),
+		testutil.RuleError(`Variable "$a" of type "Dog" used in position expecting type "ComplexInput".`, 2, 17, 5, 42),
+		testutil.RuleError(`Variable "$b" of type "[[CatOrDog!]]!" used in position expecting type "[String]".`, 2, 26, 6, 48),
+	})
+}
+
+func TestValidate_ScalarLeafs_ObjectTypeMissingSelection(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query directQueryOnObjectWithoutSubFields {
+        human
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "human" of type "Human" must have a sub selection.`, 3, 9),
+	})
+}
+
+func TestValidate_ScalarLeafs_ScalarSelectionNotAllowedOnBoolean(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog {
+          barks { sinceWhen }
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "barks" of type "Boolean" must not have a sub selection.`, 4, 17),
+	})
+}
+
+func TestValidate_FieldsOnCorrectType_ReportsErrorsWhenTypeIsKnownAgain(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog {
+          unknown_pet_field {
+            ... on Cat {
+              unknown_cat_field
+            }
+          }
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot query field "unknown_pet_field" on "Dog".`, 4, 11),
+		testutil.RuleError(`Cannot query field "unknown_cat_field" on "Cat".`, 6, 15),
+	})
+}
+
+func TestValidate_FieldsOnCorrectType_FieldNotDefinedOnUnion(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        catOrDog {
+          name
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot query field "name" on "CatOrDog".`, 4, 11),
+	})
+}
+
+func TestValidate_UniqueFragmentNames_FragmentsNamedTheSame(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog {
+          ...fragA
+        }
+      }
+      fragment fragA on Dog {
+        name
+      }
+      fragment fragA on Dog {
+        barks
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can only be one fragment named "fragA".`, 7, 16, 10, 16),
+	})
+}
+
+func TestValidate_KnownFragmentNames_UnknownFragmentNamesAreInvalid(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        human(id: 4) {
+          ...UnknownFragment1
+          ... on Human {
+            ...UnknownFragment2
+          }
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Unknown fragment "UnknownFragment1".`, 4, 14),
+		testutil.RuleError(`Unknown fragment "UnknownFragment2".`, 6, 16),
+	})
+}
+
+func TestValidate_NoUnusedFragments_ContainsUnknownAndUndefFragments(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query Foo {
+        human(id: 4) {
+          ...HumanFields1
+        }
+      }
+      fragment HumanFields1 on Human {
+        name
+      }
+      fragment Unused1 on Human {
+        name
+      }
+      fragment Unused2 on Human {
+        name
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fragment "Unused1" is never used.`, 10, 7),
+		testutil.RuleError(`Fragment "Unused2" is never used.`, 13, 7),
+	})
+}
+
+func TestValidate_PossibleFragmentSpreads_DifferentObjectIntoObject(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog { ...invalidObjectWithinObject }
+      }
+      fragment invalidObjectWithinObject on Cat { meowVolume }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fragment "invalidObjectWithinObject" cannot be spread here as objects of type "Dog" can never be of type "Cat".`, 3, 15),
+	})
+}
+
+func TestValidate_PossibleFragmentSpreads_InterfaceIntoOverlappingUnion(t *testing.T) {
+	testutil.ExpectValid(t, testutil.DefaultRulesTestSchema, `
+      {
+        catOrDog { ...petFragment }
+      }
+      fragment petFragment on Pet { name }
+    `)
+}
+
+func TestValidate_PossibleFragmentSpreads_InlineFragmentIntoNonOverlappingUnion(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        humanOrAlien {
+          ... on Dog { barkVolume }
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fragment cannot be spread here as objects of type "HumanOrAlien" can never be of type "Dog".`, 4, 11),
+	})
+}
+
+func TestValidate_NoFragmentCycles_SpreadingRecursivelyWithinFieldFails(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        human { ...fragA }
+      }
+      fragment fragA on Human { relatives { ...fragA } },
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot spread fragment "fragA" within itself.`, 5, 45),
+	})
+}
+
+func TestValidate_NoFragmentCycles_NoSpreadingItselfIndirectly(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog { ...fragA }
+      }
+      fragment fragA on Dog { ...fragB }
+      fragment fragB on Dog { ...fragA }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot spread fragment "fragA" within itself via fragB.`, 5, 31, 6, 31),
+	})
+}
+
+func TestValidate_NoUndefinedVariables_VariableNotDefinedByUnnamedQuery(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        complicatedArgs {
+          intArgField(intArg: $a)
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$a" is not defined.`, 4, 31, 2, 7),
+	})
+}
+
+func TestValidate_NoUndefinedVariables_VariableWithinFragmentNotDefined(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query Foo($a: Int) {
+        complicatedArgs {
+          ...FragA
+        }
+      }
+      fragment FragA on ComplicatedArgs {
+        intArgField(intArg: $a)
+        stringArgField(stringArg: $b)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$b" is not defined by operation "Foo".`, 9, 35, 2, 7),
+	})
+}
+
+func TestValidate_NoUnusedVariables_VariableNotUsedByFragment(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query Foo($a: Int, $b: String) {
+        complicatedArgs {
+          ...FragA
+        }
+      }
+      fragment FragA on ComplicatedArgs {
+        intArgField(intArg: $a)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$b" is never used in operation "Foo".`, 2, 26),
+	})
+}
+
+func TestValidate_KnownDirectives_UnknownAndMisplacedDirectives(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query Foo @include(if: true) {
+        dog @unknown(directive: "value") {
+          name
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "include" may not be used on QUERY.`, 2, 17),
+		testutil.RuleError(`Unknown directive "unknown".`, 3, 13),
+	})
+}
+
+func TestValidate_KnownArgumentNames_UnknownArgumentsOnFieldAndDirective(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog {
+          doesKnowCommand(unknown: true)
+          name @skip(unless: true, if: false)
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Unknown argument "unknown" on field "doesKnowCommand" of type "Dog".`, 4, 27),
+		testutil.RuleError(`Unknown argument "unless" on directive "@skip".`, 5, 22),
+	})
+}
+
+func TestValidate_UniqueArgumentNames_DuplicateFieldArguments(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog {
+          isAtLocation(x: 1, x: 1)
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can be only one argument named "x".`, 4, 24, 4, 30),
+	})
+}
+
+func TestValidate_ArgumentsOfCorrectType_InvalidLiteralValues(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        complicatedArgs {
+          intArgField(intArg: "3")
+          booleanArgField(booleanArg: 2)
+          enumArgField(enumArg: "BLACK")
+          complexArgField(complexArg: { intField: 4 })
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Argument "intArg" expected type "Int" but got: "3".`, 4, 31),
+		testutil.RuleError(`Argument "booleanArg" expected type "Boolean" but got: 2.`, 5, 39),
+		testutil.RuleError(`Argument "enumArg" expected type "FurColor" but got: "BLACK".`, 6, 33),
+		testutil.RuleError(`Argument "complexArg" expected type "ComplexInput" but got: {intField: 4}.`, 7, 39),
+	})
+}
+
+func TestValidate_ArgumentsOfCorrectType_ValidLiteralValues(t *testing.T) {
+	testutil.ExpectValid(t, testutil.DefaultRulesTestSchema, `
+      {
+        complicatedArgs {
+          intArgField(intArg: 2)
+          floatArgField(floatArg: 1)
+          idArgField(idArg: 1)
+          stringListArgField(stringListArg: "one")
+          complexArgField(complexArg: { requiredField: true, stringListField: ["one", "two"] })
+        }
+      }
+    `)
+}
+
+func TestValidate_ProvidedNonNullArguments_MissingRequiredArguments(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        complicatedArgs {
+          multipleReqs(req2: 2)
+        }
+        dog @include {
+          name
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "multipleReqs" argument "req1" of type "Int!" is required but not provided.`, 4, 11),
+		testutil.RuleError(`Directive "@include" argument "if" of type "Boolean!" is required but not provided.`, 6, 13),
+	})
+}
+
+func TestValidate_DefaultValuesOfCorrectType_InvalidDefaultValues(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query InvalidDefaultValues($a: Int! = 1, $b: String = 2) {
+        complicatedArgs {
+          nonNullIntArgField(nonNullIntArg: $a)
+          stringArgField(stringArg: $b)
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$a" of type "Int!" is required and will not use the default value. Perhaps you meant to use type "Int".`, 2, 45),
+		testutil.RuleError(`Variable "$b" of type "String" has invalid default value: 2.`, 2, 61),
+	})
+}
+
+func TestValidate_VariablesInAllowedPosition_NullableIntoNonNull(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      query Query($intArg: Int, $boolArg: Boolean = true) {
+        complicatedArgs {
+          nonNullIntArgField(nonNullIntArg: $intArg)
+        }
+        dog @include(if: $boolArg) {
+          name
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$intArg" of type "Int" used in position expecting type "Int!".`, 2, 19, 4, 45),
+	})
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_DifferentFieldsWithSameAlias(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog {
+          name: nickname
+          name
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fields "name" conflict because nickname and name are different fields.`, 4, 11, 5, 11),
+	})
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_DeepConflict(t *testing.T) {
+	testutil.ExpectInvalid(t, testutil.DefaultRulesTestSchema, `
+      {
+        dog {
+          x: name
+        }
+        dog {
+          x: barks
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fields "dog" conflict because subfields "x" conflict because name and barks are different fields.`, 3, 9, 4, 11, 6, 9, 7, 11),
+	})
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_AllowsDifferingFieldsOnDisjointObjects(t *testing.T) {
+	testutil.ExpectValid(t, testutil.DefaultRulesTestSchema, `
+      {
+        catOrDog {
+          ... on Dog {
+            name: nickname
+          }
+          ... on Cat {
+            name
+          }
+        }
+      }
+    `)
+}
+
+func TestValidate_SpecifiedRules_AcceptsValidDocument(t *testing.T) {
+	testutil.ExpectValid(t, testutil.DefaultRulesTestSchema, `
+      query Foo($atOtherHomes: Boolean = true, $skip: Boolean!) {
+        dog {
+          ...DogFields
+          isHousetrained(atOtherHomes: $atOtherHomes)
+        }
+        pet {
+          name @skip(if: $skip)
+          ... on Cat {
+            meows
+          }
+        }
+        human(id: "1") {
+          relatives {
+            name
+          }
+        }
+      }
+      fragment DogFields on Dog {
+        name
+        doesKnowCommand(dogCommand: SIT)
+      }
+    `)
+}
+
+// noIntrospectionRule is a custom rule which forbids introspection queries.
+func noIntrospectionRule(context *graphql.ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Field)
+					fieldDef := context.GetFieldDef()
+					if ok && (fieldDef == graphql.SchemaMetaFieldDef || fieldDef == graphql.TypeMetaFieldDef) {
+						context.ReportError(graphql.NewLocatedError(
+							fmt.Sprintf(`Introspection of "%v" is not allowed.`, context.GetParentType().GetName()),
+							[]ast.Node{node},
+						))
+						return visitor.ActionSkip, nil
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+// noDogNamesRule is a custom rule which relies on the TypeInfo-style context
+// to forbid selecting the name of a Dog, however it is reached.
+func noDogNamesRule(context *graphql.ValidationContext) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Field)
+					parentType := context.GetParentType()
+					fieldDef := context.GetFieldDef()
+					if ok && parentType != nil && fieldDef != nil &&
+						parentType.GetName() == "Dog" && fieldDef.Name == "name" {
+						context.ReportError(graphql.NewLocatedError(
+							fmt.Sprintf(`Field "%v" of type "%v" may not be queried on "Dog".`, fieldDef.Name, context.GetType()),
+							[]ast.Node{node},
+						))
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+}
+
+func TestValidate_CustomRules_ReportsErrorsUsingTypeInfo(t *testing.T) {
+	testutil.ExpectFailsRule(t, noDogNamesRule, `
+      {
+        dog { name }
+        pet {
+          name
+          ... on Dog { barks, nickname: name }
+        }
+        catOrDog { ...DogName }
+      }
+      fragment DogName on Dog { name }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "name" of type "String" may not be queried on "Dog".`, 3, 15),
+		testutil.RuleError(`Field "name" of type "String" may not be queried on "Dog".`, 6, 31),
+		testutil.RuleError(`Field "name" of type "String" may not be queried on "Dog".`, 10, 33),
+	})
+}
+
+func TestValidate_CustomRules_RunAlongsideSpecifiedRules(t *testing.T) {
+	rules := append([]graphql.ValidationRule{}, graphql.SpecifiedRules...)
+	rules = append(rules, noDogNamesRule)
+
+	doc := testutil.TestParse(t, `
+      {
+        dog { name, unknownField }
+      }
+    `)
+	result := graphql.ValidateDocumentWithRules(*testutil.DefaultRulesTestSchema, doc, rules)
+	expectedErrors := []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "name" of type "String" may not be queried on "Dog".`, 3, 15),
+		testutil.RuleError(`Cannot query field "unknownField" on "Dog".`, 3, 21),
+	}
+	if result.IsValid {
+		t.Fatalf("Expected query to be invalid")
+	}
+	if !reflect.DeepEqual(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
+
+func TestValidate_CustomRules_EmptyRulesAcceptAnyDocument(t *testing.T) {
+	doc := testutil.TestParse(t, `{ unknownField }`)
+	result := graphql.ValidateDocumentWithRules(*testutil.DefaultRulesTestSchema, doc, []graphql.ValidationRule{})
+	if !result.IsValid || len(result.Errors) > 0 {
+		t.Fatalf("Expected query to be valid, got errors: %v", result.Errors)
+	}
+}
+
+func TestValidate_CustomRules_UsedByGraphqlParams(t *testing.T) {
+	rules := append([]graphql.ValidationRule{}, graphql.SpecifiedRules...)
+	rules = append(rules, noIntrospectionRule)
+
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:          testutil.StarWarsSchema,
+		RequestString:   `{ hero { name } __schema { queryType { name } } }`,
+		ValidationRules: rules,
+	}))
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			testutil.RuleError(`Introspection of "Query" is not allowed.`, 1, 17),
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:          testutil.StarWarsSchema,
+		RequestString:   `{ hero { name } }`,
+		ValidationRules: rules,
+	}))
+	expected = &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "R2-D2",
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
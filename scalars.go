@@ -98,7 +98,7 @@ var Float *Scalar = NewScalar(ScalarConfig{
 				return floatValue
 			}
 		}
-		return float32(0)
+		return nil
 	},
 })
 
@@ -115,7 +115,7 @@ var String *Scalar = NewScalar(ScalarConfig{
 		case *ast.StringValue:
 			return valueAST.Value
 		}
-		return ""
+		return nil
 	},
 })
 
@@ -157,7 +157,7 @@ var Boolean *Scalar = NewScalar(ScalarConfig{
 		case *ast.BooleanValue:
 			return valueAST.Value
 		}
-		return false
+		return nil
 	},
 })
 
@@ -172,6 +172,6 @@ var ID *Scalar = NewScalar(ScalarConfig{
 		case *ast.StringValue:
 			return valueAST.Value
 		}
-		return ""
+		return nil
 	},
 })
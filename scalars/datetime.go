@@ -0,0 +1,149 @@
+package scalars
+
+import (
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	// DateLayout is the layout of the values of Date, a RFC 3339 full-date.
+	DateLayout = "2006-01-02"
+	// TimeLayout is the layout of the values of Time, a RFC 3339 partial-time.
+	TimeLayout = "15:04:05.999999999"
+)
+
+// timeFormatter returns a SerializeFn formatting the time.Time values, and
+// the strings the layout parses, with the layout.
+func timeFormatter(layout string) graphql.SerializeFn {
+	return func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case time.Time:
+			return value.Format(layout)
+		case *time.Time:
+			if value == nil {
+				return nil
+			}
+			return value.Format(layout)
+		case string:
+			if t, err := time.Parse(layout, value); err == nil {
+				return t.Format(layout)
+			}
+		}
+		return nil
+	}
+}
+
+// timeParser returns a function parsing strings of the layout to time.Time.
+func timeParser(layout string) func(value string) interface{} {
+	return func(value string) interface{} {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return nil
+		}
+		return t
+	}
+}
+
+// parseTime returns a ParseValueFn accepting time.Time values, and strings
+// of the layout.
+func parseTime(layout string) graphql.ParseValueFn {
+	parseLayout := parseString(timeParser(layout))
+	return func(value interface{}) interface{} {
+		if value, ok := value.(time.Time); ok {
+			return value
+		}
+		return parseLayout(value)
+	}
+}
+
+/**
+ * DateTime is a date and time, represented as a RFC 3339 date-time string
+ * such as "2016-01-02T15:04:05.999Z".
+ *
+ * It serializes time.Time values and is parsed to time.Time values, keeping
+ * the offset of the input.
+ */
+var DateTime *graphql.Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "DateTime",
+	Description: "A date and time, represented as a RFC 3339 date-time string, " +
+		`such as "2016-01-02T15:04:05Z".`,
+	Serialize:    timeFormatter(time.RFC3339Nano),
+	ParseValue:   parseTime(time.RFC3339Nano),
+	ParseLiteral: parseStringLiteral(timeParser(time.RFC3339Nano)),
+})
+
+/**
+ * Date is a calendar date, represented as a RFC 3339 full-date string such
+ * as "2016-01-02".
+ *
+ * It serializes the date of time.Time values, in their location, and is
+ * parsed to time.Time values at midnight UTC.
+ */
+var Date *graphql.Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "Date",
+	Description:  `A calendar date, represented as a RFC 3339 full-date string, such as "2016-01-02".`,
+	Serialize:    timeFormatter(DateLayout),
+	ParseValue:   parseTime(DateLayout),
+	ParseLiteral: parseStringLiteral(timeParser(DateLayout)),
+})
+
+/**
+ * Time is a time of the day, represented as a RFC 3339 partial-time string
+ * such as "15:04:05" or "15:04:05.999".
+ *
+ * It serializes the clock of time.Time values, in their location, and is
+ * parsed to time.Time values of January 1, year 0, UTC.
+ */
+var Time *graphql.Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Time",
+	Description: "A time of the day, represented as a RFC 3339 partial-time string, " +
+		`such as "15:04:05".`,
+	Serialize:    timeFormatter(TimeLayout),
+	ParseValue:   parseTime(TimeLayout),
+	ParseLiteral: parseStringLiteral(timeParser(TimeLayout)),
+})
+
+func serializeDuration(value interface{}) interface{} {
+	switch value := value.(type) {
+	case time.Duration:
+		return value.String()
+	case *time.Duration:
+		if value == nil {
+			return nil
+		}
+		return value.String()
+	case string:
+		if d, err := time.ParseDuration(value); err == nil {
+			return d.String()
+		}
+	}
+	return nil
+}
+
+func parseDuration(value string) interface{} {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+/**
+ * Duration is an elapsed time, represented as a Go duration string such as
+ * "1h30m" or "250ms", see time.ParseDuration.
+ *
+ * It serializes time.Duration values and is parsed to time.Duration values.
+ */
+var Duration *graphql.Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Duration",
+	Description: `An elapsed time, represented as a duration string, such as "1h30m" or "250ms".`,
+	Serialize:   serializeDuration,
+	ParseValue: func(value interface{}) interface{} {
+		if value, ok := value.(time.Duration); ok {
+			return value
+		}
+		return parseString(parseDuration)(value)
+	},
+	ParseLiteral: parseStringLiteral(parseDuration),
+})
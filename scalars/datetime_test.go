@@ -0,0 +1,149 @@
+package scalars_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/scalars"
+)
+
+type scalarTest struct {
+	Value    interface{}
+	Expected interface{}
+}
+
+func stringValue(value string) ast.Value {
+	return ast.NewStringValue(&ast.StringValue{Value: value})
+}
+
+func intValue(value string) ast.Value {
+	return ast.NewIntValue(&ast.IntValue{Value: value})
+}
+
+func TestDateTime_Serializes(t *testing.T) {
+	paris := time.FixedZone("Paris", 2*60*60)
+	date := time.Date(2016, time.January, 2, 15, 4, 5, 0, time.UTC)
+	tests := []scalarTest{
+		{date, "2016-01-02T15:04:05Z"},
+		{&date, "2016-01-02T15:04:05Z"},
+		{time.Date(2016, time.January, 2, 15, 4, 5, 250000000, paris), "2016-01-02T15:04:05.25+02:00"},
+		{"2016-01-02T15:04:05.000+02:00", "2016-01-02T15:04:05+02:00"},
+		{(*time.Time)(nil), nil},
+		{"2016-01-02", nil},
+		{1451747045, nil},
+	}
+	for _, test := range tests {
+		if value := scalars.DateTime.Serialize(test.Value); value != test.Expected {
+			t.Fatalf("Failed DateTime.Serialize(%v), expected: %v, got %v", test.Value, test.Expected, value)
+		}
+	}
+}
+
+func TestDateTime_Parses(t *testing.T) {
+	date := time.Date(2016, time.January, 2, 15, 4, 5, 250000000, time.FixedZone("", -5*60*60))
+	for _, value := range []interface{}{"2016-01-02T15:04:05.25-05:00", date} {
+		parsed, ok := scalars.DateTime.ParseValue(value).(time.Time)
+		if !ok || !parsed.Equal(date) {
+			t.Fatalf("Failed DateTime.ParseValue(%v), got %v", value, parsed)
+		}
+	}
+	parsed, ok := scalars.DateTime.ParseLiteral(stringValue("2016-01-02T20:04:05.25Z")).(time.Time)
+	if !ok || !parsed.Equal(date) {
+		t.Fatalf("Failed DateTime.ParseLiteral, got %v", parsed)
+	}
+	if _, offset := parsed.Zone(); offset != 0 {
+		t.Fatalf("Expected the offset of the input to be kept, got %v", offset)
+	}
+
+	for _, value := range []interface{}{"2016-01-02", "2016-01-02 15:04:05Z", "tomorrow", 1451747045} {
+		if parsed := scalars.DateTime.ParseValue(value); parsed != nil {
+			t.Fatalf("Expected DateTime.ParseValue(%v) to fail, got %v", value, parsed)
+		}
+	}
+	for _, valueAST := range []ast.Value{stringValue("2016-13-02T15:04:05Z"), intValue("1451747045")} {
+		if parsed := scalars.DateTime.ParseLiteral(valueAST); parsed != nil {
+			t.Fatalf("Expected DateTime.ParseLiteral(%v) to fail, got %v", valueAST, parsed)
+		}
+	}
+}
+
+func TestDate_SerializesAndParses(t *testing.T) {
+	tests := []scalarTest{
+		{time.Date(2016, time.January, 2, 23, 4, 5, 0, time.UTC), "2016-01-02"},
+		{"2016-01-02", "2016-01-02"},
+		{"2016-01-02T15:04:05Z", nil},
+		{"2016-02-30", nil},
+	}
+	for _, test := range tests {
+		if value := scalars.Date.Serialize(test.Value); value != test.Expected {
+			t.Fatalf("Failed Date.Serialize(%v), expected: %v, got %v", test.Value, test.Expected, value)
+		}
+	}
+
+	expected := time.Date(2016, time.January, 2, 0, 0, 0, 0, time.UTC)
+	if parsed := scalars.Date.ParseValue("2016-01-02"); parsed != expected {
+		t.Fatalf("Failed Date.ParseValue, expected: %v, got %v", expected, parsed)
+	}
+	if parsed := scalars.Date.ParseLiteral(stringValue("2016-01-02")); parsed != expected {
+		t.Fatalf("Failed Date.ParseLiteral, expected: %v, got %v", expected, parsed)
+	}
+	if parsed := scalars.Date.ParseValue("01/02/2016"); parsed != nil {
+		t.Fatalf("Expected Date.ParseValue to fail, got %v", parsed)
+	}
+}
+
+func TestTime_SerializesAndParses(t *testing.T) {
+	tests := []scalarTest{
+		{time.Date(2016, time.January, 2, 15, 4, 5, 0, time.UTC), "15:04:05"},
+		{time.Date(2016, time.January, 2, 15, 4, 5, 120000000, time.UTC), "15:04:05.12"},
+		{"15:04:05.000", "15:04:05"},
+		{"25:04:05", nil},
+	}
+	for _, test := range tests {
+		if value := scalars.Time.Serialize(test.Value); value != test.Expected {
+			t.Fatalf("Failed Time.Serialize(%v), expected: %v, got %v", test.Value, test.Expected, value)
+		}
+	}
+
+	expected := time.Date(0, time.January, 1, 15, 4, 5, 500000000, time.UTC)
+	if parsed := scalars.Time.ParseValue("15:04:05.5"); parsed != expected {
+		t.Fatalf("Failed Time.ParseValue, expected: %v, got %v", expected, parsed)
+	}
+	if parsed := scalars.Time.ParseLiteral(stringValue("15:04:05.5")); parsed != expected {
+		t.Fatalf("Failed Time.ParseLiteral, expected: %v, got %v", expected, parsed)
+	}
+	if parsed := scalars.Time.ParseLiteral(stringValue("3pm")); parsed != nil {
+		t.Fatalf("Expected Time.ParseLiteral to fail, got %v", parsed)
+	}
+}
+
+func TestDuration_SerializesAndParses(t *testing.T) {
+	tests := []scalarTest{
+		{90 * time.Minute, "1h30m0s"},
+		{time.Duration(0), "0s"},
+		{"250ms", "250ms"},
+		{"1h90m", "2h30m0s"},
+		{"forever", nil},
+		{int64(time.Second), nil},
+	}
+	for _, test := range tests {
+		if value := scalars.Duration.Serialize(test.Value); value != test.Expected {
+			t.Fatalf("Failed Duration.Serialize(%v), expected: %v, got %v", test.Value, test.Expected, value)
+		}
+	}
+
+	parsed := []interface{}{
+		scalars.Duration.ParseValue("1h30m"),
+		scalars.Duration.ParseValue(90 * time.Minute),
+		scalars.Duration.ParseLiteral(stringValue("90m")),
+	}
+	expected := []interface{}{90 * time.Minute, 90 * time.Minute, 90 * time.Minute}
+	if !reflect.DeepEqual(expected, parsed) {
+		t.Fatalf("Failed to parse durations, expected: %v, got %v", expected, parsed)
+	}
+	if parsed := scalars.Duration.ParseLiteral(intValue("90")); parsed != nil {
+		t.Fatalf("Expected Duration.ParseLiteral to fail, got %v", parsed)
+	}
+}
@@ -0,0 +1,108 @@
+package scalars
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+func coerceInt64(value interface{}) interface{} {
+	if value, ok := value.(string); ok {
+		return parseInt64(value)
+	}
+	if intValue, ok := toInt64(value); ok {
+		return intValue
+	}
+	return nil
+}
+
+func parseInt64(value string) interface{} {
+	intValue, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return intValue
+}
+
+// integerLiteral returns a ParseLiteralFn accepting the int and string
+// literals parse accepts.
+func integerLiteral(parse func(value string) interface{}) graphql.ParseLiteralFn {
+	return func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			return parse(valueAST.Value)
+		case *ast.StringValue:
+			return parse(valueAST.Value)
+		}
+		return nil
+	}
+}
+
+/**
+ * Int64 is a signed 64-bit integer, serialized as a string since JSON numbers
+ * only represent integers exactly up to 2^53.
+ *
+ * It serializes the Go integers, and strings of integers, and is parsed to
+ * int64 values, from strings or int literals, as well as from numbers
+ * holding an integer up to 2^53.
+ */
+var Int64 *graphql.Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Int64",
+	Description: "A signed 64-bit integer, represented as a string of decimal digits.",
+	Serialize: func(value interface{}) interface{} {
+		if intValue, ok := coerceInt64(value).(int64); ok {
+			return strconv.FormatInt(intValue, 10)
+		}
+		return nil
+	},
+	ParseValue:   coerceInt64,
+	ParseLiteral: integerLiteral(parseInt64),
+})
+
+func coerceBigInt(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *big.Int:
+		if value == nil {
+			return nil
+		}
+		return value
+	case big.Int:
+		return &value
+	case string:
+		return parseBigInt(value)
+	}
+	if intValue, ok := toInt64(value); ok {
+		return big.NewInt(intValue)
+	}
+	return nil
+}
+
+func parseBigInt(value string) interface{} {
+	intValue, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil
+	}
+	return intValue
+}
+
+/**
+ * BigInt is an integer of any size, serialized as a string.
+ *
+ * It serializes *big.Int values, the Go integers, and strings of integers,
+ * and is parsed to *big.Int values, from strings or int literals, as well as
+ * from numbers holding an integer up to 2^53.
+ */
+var BigInt *graphql.Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "BigInt",
+	Description: "An integer of any size, represented as a string of decimal digits.",
+	Serialize: func(value interface{}) interface{} {
+		if intValue, ok := coerceBigInt(value).(*big.Int); ok {
+			return intValue.String()
+		}
+		return nil
+	},
+	ParseValue:   coerceBigInt,
+	ParseLiteral: integerLiteral(parseBigInt),
+})
@@ -0,0 +1,99 @@
+package scalars_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/scalars"
+)
+
+func TestInt64_Serializes(t *testing.T) {
+	tests := []scalarTest{
+		{int64(math.MaxInt64), "9223372036854775807"},
+		{int64(math.MinInt64), "-9223372036854775808"},
+		{0, "0"},
+		{int32(-12), "-12"},
+		{uint64(12), "12"},
+		{float64(1 << 40), "1099511627776"},
+		{"9223372036854775807", "9223372036854775807"},
+		{uint64(math.MaxUint64), nil},
+		{"9223372036854775808", nil},
+		{1.5, nil},
+		{"one", nil},
+		{true, nil},
+	}
+	for _, test := range tests {
+		if value := scalars.Int64.Serialize(test.Value); value != test.Expected {
+			t.Fatalf("Failed Int64.Serialize(%T(%v)), expected: %v, got %v", test.Value, test.Value, test.Expected, value)
+		}
+	}
+}
+
+func TestInt64_Parses(t *testing.T) {
+	tests := []scalarTest{
+		{"-9223372036854775808", int64(math.MinInt64)},
+		{float64(42), int64(42)},
+		{42, int64(42)},
+		{float64(1 << 60), nil}, // not exactly represented by a float
+		{"4.2", nil},
+		{"", nil},
+	}
+	for _, test := range tests {
+		if value := scalars.Int64.ParseValue(test.Value); value != test.Expected {
+			t.Fatalf("Failed Int64.ParseValue(%T(%v)), expected: %v, got %v", test.Value, test.Value, test.Expected, value)
+		}
+	}
+
+	literals := []struct {
+		ValueAST ast.Value
+		Expected interface{}
+	}{
+		{intValue("9223372036854775807"), int64(math.MaxInt64)},
+		{stringValue("-42"), int64(-42)},
+		{intValue("9223372036854775808"), nil},
+		{ast.NewFloatValue(&ast.FloatValue{Value: "4.0"}), nil},
+	}
+	for _, test := range literals {
+		if value := scalars.Int64.ParseLiteral(test.ValueAST); value != test.Expected {
+			t.Fatalf("Failed Int64.ParseLiteral(%v), expected: %v, got %v", test.ValueAST, test.Expected, value)
+		}
+	}
+}
+
+func TestBigInt_SerializesAndParses(t *testing.T) {
+	huge, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	tests := []scalarTest{
+		{huge, "-123456789012345678901234567890"},
+		{*huge, "-123456789012345678901234567890"},
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+		{int64(math.MinInt64), "-9223372036854775808"},
+		{7, "7"},
+		{(*big.Int)(nil), nil},
+		{"1e10", nil},
+		{2.5, nil},
+	}
+	for _, test := range tests {
+		if value := scalars.BigInt.Serialize(test.Value); value != test.Expected {
+			t.Fatalf("Failed BigInt.Serialize(%T(%v)), expected: %v, got %v", test.Value, test.Value, test.Expected, value)
+		}
+	}
+
+	parsed := []interface{}{
+		scalars.BigInt.ParseValue("-123456789012345678901234567890"),
+		scalars.BigInt.ParseLiteral(intValue("-123456789012345678901234567890")),
+		scalars.BigInt.ParseLiteral(stringValue("-123456789012345678901234567890")),
+	}
+	for _, value := range parsed {
+		if value, ok := value.(*big.Int); !ok || value.Cmp(huge) != 0 {
+			t.Fatalf("Failed to parse BigInt, expected: %v, got %v", huge, value)
+		}
+	}
+	if value, ok := scalars.BigInt.ParseValue(float64(42)).(*big.Int); !ok || value.Int64() != 42 {
+		t.Fatalf("Failed BigInt.ParseValue(42), got %v", value)
+	}
+	if value := scalars.BigInt.ParseLiteral(stringValue("forty-two")); value != nil {
+		t.Fatalf("Expected BigInt.ParseLiteral to fail, got %v", value)
+	}
+}
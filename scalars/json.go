@@ -0,0 +1,83 @@
+package scalars
+
+import (
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// parseJSONLiteral returns the Go value of a literal, objects being parsed to
+// map[string]interface{} and lists to []interface{} values. Enum values and
+// variables are not JSON values.
+func parseJSONLiteral(valueAST ast.Value) (interface{}, bool) {
+	switch valueAST := valueAST.(type) {
+	case *ast.StringValue:
+		return valueAST.Value, true
+	case *ast.BooleanValue:
+		return valueAST.Value, true
+	case *ast.IntValue:
+		if intValue, err := strconv.Atoi(valueAST.Value); err == nil {
+			return intValue, true
+		}
+		// integers too large for an int are kept as floats, like in JSON
+		if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return floatValue, true
+		}
+	case *ast.FloatValue:
+		if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return floatValue, true
+		}
+	case *ast.ListValue:
+		values := []interface{}{}
+		for _, itemAST := range valueAST.Values {
+			value, ok := parseJSONLiteral(itemAST)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, value)
+		}
+		return values, true
+	case *ast.ObjectValue:
+		values := map[string]interface{}{}
+		for _, field := range valueAST.Fields {
+			if field == nil || field.Name == nil {
+				return nil, false
+			}
+			value, ok := parseJSONLiteral(field.Value)
+			if !ok {
+				return nil, false
+			}
+			values[field.Name.Value] = value
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+/**
+ * JSON is an arbitrary JSON value, such as an object of settings whose shape
+ * is not part of the schema.
+ *
+ * Values are serialized and parsed as they are, so that the Go value of an
+ * object variable is its map[string]interface{} value. Literals are parsed to
+ * the same Go values: `{tags: ["a", "b"], limit: 10}` becomes a
+ * map[string]interface{} holding a []interface{} and an int.
+ */
+var JSON *graphql.Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "An arbitrary JSON value.",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		value, ok := parseJSONLiteral(valueAST)
+		if !ok {
+			return nil
+		}
+		return value
+	},
+})
@@ -0,0 +1,69 @@
+package scalars_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/scalars"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// parseValueLiteral parses the value of the `value` argument of the query.
+func parseValueLiteral(t *testing.T, value string) ast.Value {
+	doc := testutil.TestParse(t, `{ field(value: `+value+`) }`)
+	field := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	return field.Arguments[0].Value
+}
+
+func TestJSON_SerializesAndParsesValuesAsTheyAre(t *testing.T) {
+	values := []interface{}{
+		map[string]interface{}{"a": []interface{}{1.5, "b", true}},
+		[]interface{}{"a", "b"},
+		"a",
+		false,
+	}
+	for _, value := range values {
+		if serialized := scalars.JSON.Serialize(value); !reflect.DeepEqual(value, serialized) {
+			t.Fatalf("Failed JSON.Serialize(%v), got %v", value, serialized)
+		}
+		if parsed := scalars.JSON.ParseValue(value); !reflect.DeepEqual(value, parsed) {
+			t.Fatalf("Failed JSON.ParseValue(%v), got %v", value, parsed)
+		}
+	}
+}
+
+func TestJSON_ParsesLiterals(t *testing.T) {
+	tests := []scalarTest{
+		{`"a"`, "a"},
+		{`true`, true},
+		{`-12`, -12},
+		{`1.5`, 1.5},
+		{`123456789012345678901234567890`, 1.2345678901234568e+29},
+		{`[]`, []interface{}{}},
+		{`{}`, map[string]interface{}{}},
+		{`{name: "a", tags: ["b", 1, 2.5], nested: {deep: [{ok: false}]}}`, map[string]interface{}{
+			"name": "a",
+			"tags": []interface{}{"b", 1, 2.5},
+			"nested": map[string]interface{}{
+				"deep": []interface{}{
+					map[string]interface{}{"ok": false},
+				},
+			},
+		}},
+	}
+	for _, test := range tests {
+		parsed := scalars.JSON.ParseLiteral(parseValueLiteral(t, test.Value.(string)))
+		if !reflect.DeepEqual(test.Expected, parsed) {
+			t.Fatalf("Failed JSON.ParseLiteral(%v), Diff: %v", test.Value, testutil.Diff(test.Expected, parsed))
+		}
+	}
+}
+
+func TestJSON_RejectsEnumsAndVariablesInLiterals(t *testing.T) {
+	for _, value := range []string{`RED`, `[1, RED]`, `{color: RED}`, `$var`, `{nested: [$var]}`} {
+		if parsed := scalars.JSON.ParseLiteral(parseValueLiteral(t, value)); parsed != nil {
+			t.Fatalf("Expected JSON.ParseLiteral(%v) to fail, got %v", value, parsed)
+		}
+	}
+}
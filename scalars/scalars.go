@@ -0,0 +1,70 @@
+/**
+ * Package scalars provides custom scalars commonly needed by GraphQL
+ * schemas, beyond the Int, Float, String, Boolean and ID built-in scalars.
+ *
+ * Like the built-in scalars, they serialize the values they cannot represent
+ * to null, and their ParseValue and ParseLiteral functions return nil for the
+ * inputs they do not accept, which makes the queries and variables providing
+ * such inputs invalid.
+ *
+ * Example:
+ *
+ *     "createdAt": &graphql.FieldConfig{
+ *       Type: scalars.DateTime,
+ *     },
+ */
+package scalars
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// parseString returns a ParseValueFn accepting the strings parse accepts.
+func parseString(parse func(value string) interface{}) func(value interface{}) interface{} {
+	return func(value interface{}) interface{} {
+		if value, ok := value.(string); ok {
+			return parse(value)
+		}
+		return nil
+	}
+}
+
+// parseStringLiteral returns a ParseLiteralFn accepting the string literals
+// parse accepts.
+func parseStringLiteral(parse func(value string) interface{}) func(valueAST ast.Value) interface{} {
+	return func(valueAST ast.Value) interface{} {
+		if valueAST, ok := valueAST.(*ast.StringValue); ok {
+			return parse(valueAST.Value)
+		}
+		return nil
+	}
+}
+
+// toInt64 converts the Go integers, and the floats holding an integer, to an
+// int64, as long as the value fits in one.
+func toInt64(value interface{}) (int64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		// floats only represent integers exactly up to 2^53
+		f := v.Float()
+		if f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
@@ -0,0 +1,164 @@
+package scalars_test
+
+import (
+	"math/big"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/scalars"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// newEchoSchema returns a schema with a field per scalar, returning the
+// value of its argument, which is recorded by type name in received.
+func newEchoSchema(t *testing.T, received map[string]interface{}) graphql.Schema {
+	fields := graphql.FieldConfigMap{}
+	for _, scalar := range []*graphql.Scalar{
+		scalars.DateTime, scalars.Date, scalars.Time, scalars.Duration,
+		scalars.JSON, scalars.Int64, scalars.BigInt, scalars.UUID, scalars.URL,
+	} {
+		name := scalar.Name
+		fields[name] = &graphql.FieldConfig{
+			Type: scalar,
+			Args: graphql.FieldConfigArgument{
+				"value": &graphql.ArgumentConfig{Type: scalar},
+			},
+			Resolve: func(p graphql.GQLFRParams) interface{} {
+				received[name] = p.Args["value"]
+				return p.Args["value"]
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: fields,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return schema
+}
+
+func TestScalars_ParseLiteralsAndSerializeResults(t *testing.T) {
+	received := map[string]interface{}{}
+	query := `{
+      DateTime(value: "2016-01-02T15:04:05+02:00")
+      Date(value: "2016-01-02")
+      Time(value: "15:04:05")
+      Duration(value: "90m")
+      JSON(value: {tags: ["a", 1], ok: true})
+      Int64(value: 9007199254740993)
+      BigInt(value: "123456789012345678901234567890")
+      UUID(value: "123E4567-E89B-12D3-A456-426655440000")
+      URL(value: "https://example.com/path")
+    }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"DateTime": "2016-01-02T15:04:05+02:00",
+			"Date":     "2016-01-02",
+			"Time":     "15:04:05",
+			"Duration": "1h30m0s",
+			"JSON":     map[string]interface{}{"tags": []interface{}{"a", 1}, "ok": true},
+			"Int64":    "9007199254740993",
+			"BigInt":   "123456789012345678901234567890",
+			"UUID":     "123e4567-e89b-12d3-a456-426655440000",
+			"URL":      "https://example.com/path",
+		},
+	}
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:        newEchoSchema(t, received),
+		RequestString: query,
+	}))
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// resolvers receive the Go values of the scalars
+	expectedTypes := map[string]interface{}{
+		"DateTime": time.Time{},
+		"Date":     time.Time{},
+		"Time":     time.Time{},
+		"Duration": time.Duration(0),
+		"JSON":     map[string]interface{}{},
+		"Int64":    int64(0),
+		"BigInt":   &big.Int{},
+		"UUID":     "",
+		"URL":      &url.URL{},
+	}
+	for name, expectedType := range expectedTypes {
+		if reflect.TypeOf(received[name]) != reflect.TypeOf(expectedType) {
+			t.Fatalf("Unexpected argument of %v: %T", name, received[name])
+		}
+	}
+}
+
+func TestScalars_ParseVariables(t *testing.T) {
+	received := map[string]interface{}{}
+	query := `query Q($dateTime: DateTime, $json: JSON, $int64: Int64, $bigInt: BigInt) {
+      DateTime(value: $dateTime)
+      JSON(value: $json)
+      Int64(value: $int64)
+      BigInt(value: $bigInt)
+    }`
+	// variables as decoded from a JSON request body
+	variables := map[string]interface{}{
+		"dateTime": "2016-01-02T15:04:05.5Z",
+		"json":     map[string]interface{}{"nested": []interface{}{float64(1), "b"}},
+		"int64":    "-9223372036854775808",
+		"bigInt":   float64(42),
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"DateTime": "2016-01-02T15:04:05.5Z",
+			"JSON":     map[string]interface{}{"nested": []interface{}{float64(1), "b"}},
+			"Int64":    "-9223372036854775808",
+			"BigInt":   "42",
+		},
+	}
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:         newEchoSchema(t, received),
+		RequestString:  query,
+		VariableValues: variables,
+	}))
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if expected := time.Date(2016, time.January, 2, 15, 4, 5, 500000000, time.UTC); received["DateTime"] != expected {
+		t.Fatalf("Unexpected DateTime argument: %v", received["DateTime"])
+	}
+}
+
+func TestScalars_RejectInvalidInputs(t *testing.T) {
+	schema := newEchoSchema(t, map[string]interface{}{})
+	result := graphql.Graphql(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+      Date(value: "2016-02-30")
+      UUID(value: "not-a-uuid")
+      JSON(value: {color: RED})
+    }`,
+	})
+	expectedErrors := []gqlerrors.FormattedError{
+		testutil.RuleError(`Argument "value" expected type "Date" but got: "2016-02-30".`, 2, 19),
+		testutil.RuleError(`Argument "value" expected type "UUID" but got: "not-a-uuid".`, 3, 19),
+		testutil.RuleError(`Argument "value" expected type "JSON" but got: {color: RED}.`, 4, 19),
+	}
+	if !reflect.DeepEqual(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+
+	result = graphql.Graphql(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query Q($url: URL) { URL(value: $url) }`,
+		VariableValues: map[string]interface{}{"url": "/relative"},
+	})
+	if len(result.Errors) != 1 || result.Data != nil {
+		t.Fatalf("Expected the relative URL variable to be rejected, got %v", result)
+	}
+}
@@ -0,0 +1,52 @@
+package scalars
+
+import (
+	"net/url"
+
+	"github.com/graphql-go/graphql"
+)
+
+func serializeURL(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *url.URL:
+		if value == nil {
+			return nil
+		}
+		return value.String()
+	case url.URL:
+		return value.String()
+	case string:
+		if u, ok := parseURL(value).(*url.URL); ok {
+			return u.String()
+		}
+	}
+	return nil
+}
+
+// Only absolute URLs are accepted, relative references being ambiguous.
+func parseURL(value string) interface{} {
+	u, err := url.Parse(value)
+	if err != nil || !u.IsAbs() {
+		return nil
+	}
+	return u
+}
+
+/**
+ * URL is an absolute URL, such as "https://example.com/path?query".
+ *
+ * It serializes url.URL values, and strings of absolute URLs, and is parsed
+ * to *url.URL values.
+ */
+var URL *graphql.Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "URL",
+	Description: `An absolute URL, such as "https://example.com/path".`,
+	Serialize:   serializeURL,
+	ParseValue: func(value interface{}) interface{} {
+		if value, ok := value.(*url.URL); ok && value != nil && value.IsAbs() {
+			return value
+		}
+		return parseString(parseURL)(value)
+	},
+	ParseLiteral: parseStringLiteral(parseURL),
+})
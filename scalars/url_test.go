@@ -0,0 +1,42 @@
+package scalars_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/graphql-go/graphql/scalars"
+)
+
+func TestURL_SerializesAndParses(t *testing.T) {
+	u, _ := url.Parse("https://example.com/path?query=1#top")
+	tests := []scalarTest{
+		{u, "https://example.com/path?query=1#top"},
+		{*u, "https://example.com/path?query=1#top"},
+		{"mailto:someone@example.com", "mailto:someone@example.com"},
+		{(*url.URL)(nil), nil},
+		{"/relative/path", nil},
+		{"http://[::1", nil},
+		{42, nil},
+	}
+	for _, test := range tests {
+		if value := scalars.URL.Serialize(test.Value); value != test.Expected {
+			t.Fatalf("Failed URL.Serialize(%v), expected: %v, got %v", test.Value, test.Expected, value)
+		}
+	}
+
+	for _, parsed := range []interface{}{
+		scalars.URL.ParseValue("https://example.com/path?query=1#top"),
+		scalars.URL.ParseValue(u),
+		scalars.URL.ParseLiteral(stringValue("https://example.com/path?query=1#top")),
+	} {
+		if parsed, ok := parsed.(*url.URL); !ok || parsed.String() != u.String() {
+			t.Fatalf("Failed to parse URL, expected: %v, got %v", u, parsed)
+		}
+	}
+	if parsed := scalars.URL.ParseValue("example.com"); parsed != nil {
+		t.Fatalf("Expected URL.ParseValue to fail, got %v", parsed)
+	}
+	if parsed := scalars.URL.ParseLiteral(intValue("80")); parsed != nil {
+		t.Fatalf("Expected URL.ParseLiteral to fail, got %v", parsed)
+	}
+}
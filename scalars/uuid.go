@@ -0,0 +1,66 @@
+package scalars
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// canonicalUUID returns the lowercase form of a UUID string of the
+// 8-4-4-4-12 hex digits form, and false for other strings.
+func canonicalUUID(value string) (string, bool) {
+	if len(value) != 36 {
+		return "", false
+	}
+	for i, c := range value {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return "", false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return "", false
+			}
+		}
+	}
+	return strings.ToLower(value), true
+}
+
+func formatUUID(b [16]byte) string {
+	s := hex.EncodeToString(b[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
+
+func coerceUUID(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		if uuid, ok := canonicalUUID(value); ok {
+			return uuid
+		}
+	case [16]byte:
+		return formatUUID(value)
+	}
+	return nil
+}
+
+func parseUUID(value string) interface{} {
+	return coerceUUID(value)
+}
+
+/**
+ * UUID is a universally unique identifier, represented as a string of 32
+ * hexadecimal digits such as "123e4567-e89b-12d3-a456-426655440000".
+ *
+ * It serializes strings of UUIDs and [16]byte values, such as the UUID types
+ * of most UUID packages, and is parsed to lowercase UUID strings.
+ */
+var UUID *graphql.Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "UUID",
+	Description: "A universally unique identifier, represented as a string of " +
+		`hexadecimal digits, such as "123e4567-e89b-12d3-a456-426655440000".`,
+	Serialize:    coerceUUID,
+	ParseValue:   parseString(parseUUID),
+	ParseLiteral: parseStringLiteral(parseUUID),
+})
@@ -0,0 +1,38 @@
+package scalars_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/scalars"
+)
+
+func TestUUID_SerializesAndParses(t *testing.T) {
+	uuid := "123e4567-e89b-12d3-a456-426655440000"
+	tests := []scalarTest{
+		{uuid, uuid},
+		{"123E4567-E89B-12D3-A456-426655440000", uuid},
+		{[16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x55, 0x44, 0x00, 0x00}, uuid},
+		{"123e4567e89b12d3a456426655440000", nil},
+		{"123e4567-e89b-12d3-a456-42665544000g", nil},
+		{"{123e4567-e89b-12d3-a456-426655440000}", nil},
+		{[]byte(uuid), nil},
+	}
+	for _, test := range tests {
+		if value := scalars.UUID.Serialize(test.Value); value != test.Expected {
+			t.Fatalf("Failed UUID.Serialize(%v), expected: %v, got %v", test.Value, test.Expected, value)
+		}
+	}
+
+	if value := scalars.UUID.ParseValue("123E4567-E89B-12D3-A456-426655440000"); value != uuid {
+		t.Fatalf("Failed UUID.ParseValue, expected: %v, got %v", uuid, value)
+	}
+	if value := scalars.UUID.ParseLiteral(stringValue(uuid)); value != uuid {
+		t.Fatalf("Failed UUID.ParseLiteral, expected: %v, got %v", uuid, value)
+	}
+	if value := scalars.UUID.ParseValue("123e4567-e89b-12d3-a456"); value != nil {
+		t.Fatalf("Expected UUID.ParseValue to fail, got %v", value)
+	}
+	if value := scalars.UUID.ParseLiteral(intValue("123")); value != nil {
+		t.Fatalf("Expected UUID.ParseLiteral to fail, got %v", value)
+	}
+}
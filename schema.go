@@ -7,17 +7,22 @@ import (
 /**
 Schema Definition
 A Schema is created by supplying the root types of each type of operation,
-query and mutation (optional). A schema definition is then supplied to the
-validator and executor.
+query, mutation (optional) and subscription (optional). A schema definition
+is then supplied to the validator and executor.
 Example:
     myAppSchema, err := NewSchema(SchemaConfig({
       Query: MyAppQueryRootType
       Mutation: MyAppMutationRootType
+      Subscription: MyAppSubscriptionRootType
     });
 */
 type SchemaConfig struct {
-	Query    *Object
-	Mutation *Object
+	Query        *Object
+	Mutation     *Object
+	Subscription *Object
+	// Directives are the directives supported along with the specified ones,
+	// @include and @skip.
+	Directives []*Directive
 }
 
 // chose to name as TypeMap instead of TypeMap
@@ -46,14 +51,38 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 	if config.Mutation != nil && config.Mutation.err != nil {
 		return schema, config.Mutation.err
 	}
+	if config.Subscription != nil && config.Subscription.err != nil {
+		return schema, config.Subscription.err
+	}
 
 	schema.schemaConfig = config
 
+	schema.directives = []*Directive{
+		IncludeDirective,
+		SkipDirective,
+		DeprecatedDirective,
+	}
+	for _, directive := range config.Directives {
+		err = invariant(directive != nil && directive.Name != "", "Schema directives must be named Directives.")
+		if err != nil {
+			return schema, err
+		}
+		err = invariant(
+			schema.GetDirective(directive.Name) == nil,
+			fmt.Sprintf(`Schema must contain unique named directives but contains multiple directives named "%v".`, directive.Name),
+		)
+		if err != nil {
+			return schema, err
+		}
+		schema.directives = append(schema.directives, directive)
+	}
+
 	// Build type map now to detect any errors within this schema.
 	typeMap := TypeMap{}
 	objectTypes := []*Object{
 		schema.GetQueryType(),
 		schema.GetMutationType(),
+		schema.GetSubscriptionType(),
 		__Type,
 		__Schema,
 	}
@@ -69,6 +98,15 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 			return schema, err
 		}
 	}
+	// the types of the directive arguments are part of the schema as well
+	for _, directive := range schema.directives {
+		for _, arg := range directive.Args {
+			typeMap, err = typeMapReducer(typeMap, arg.Type)
+			if err != nil {
+				return schema, err
+			}
+		}
+	}
 	schema.typeMap = typeMap
 	// Enforce correct interface implementations
 	for _, ttype := range typeMap {
@@ -94,16 +132,30 @@ func (gq *Schema) GetMutationType() *Object {
 	return gq.schemaConfig.Mutation
 }
 
+func (gq *Schema) GetSubscriptionType() *Object {
+	return gq.schemaConfig.Subscription
+}
+
 func (gq *Schema) GetDirectives() []*Directive {
 	if len(gq.directives) == 0 {
 		gq.directives = []*Directive{
 			IncludeDirective,
 			SkipDirective,
+			DeprecatedDirective,
 		}
 	}
 	return gq.directives
 }
 
+func (gq *Schema) GetDirective(name string) *Directive {
+	for _, directive := range gq.GetDirectives() {
+		if directive.Name == name {
+			return directive
+		}
+	}
+	return nil
+}
+
 func (gq *Schema) GetTypeMap() TypeMap {
 	return gq.typeMap
 }
@@ -350,3 +402,116 @@ func isEqualType(typeA Type, typeB Type) bool {
 	}
 	return typeA == typeB
 }
+
+/**
+ * Provided a type and a super type, return true if the first type is either
+ * equal or a subset of the second super type (covariant).
+ */
+func isTypeSubTypeOf(maybeSubType Type, superType Type) bool {
+	// Equivalent type is a valid subtype
+	if maybeSubType == superType {
+		return true
+	}
+
+	// If superType is non-null, maybeSubType must also be nullable.
+	if superType, ok := superType.(*NonNull); ok {
+		if maybeSubType, ok := maybeSubType.(*NonNull); ok {
+			return isTypeSubTypeOf(maybeSubType.OfType, superType.OfType)
+		}
+		return false
+	}
+	if maybeSubType, ok := maybeSubType.(*NonNull); ok {
+		// If superType is nullable, maybeSubType may be non-null.
+		return isTypeSubTypeOf(maybeSubType.OfType, superType)
+	}
+
+	// If superType type is a list, maybeSubType type must also be a list.
+	if superType, ok := superType.(*List); ok {
+		if maybeSubType, ok := maybeSubType.(*List); ok {
+			return isTypeSubTypeOf(maybeSubType.OfType, superType.OfType)
+		}
+		return false
+	}
+	if _, ok := maybeSubType.(*List); ok {
+		// If superType is not a list, maybeSubType must also be not a list.
+		return false
+	}
+
+	// If superType type is an abstract type, maybeSubType type may be a currently
+	// possible object type.
+	if maybeSubType, ok := maybeSubType.(*Object); ok {
+		switch superType := superType.(type) {
+		case *Interface:
+			return superType.IsPossibleType(maybeSubType)
+		case *Union:
+			return superType.IsPossibleType(maybeSubType)
+		}
+	}
+
+	// Otherwise, the child type is not a valid subtype of the parent type.
+	return false
+}
+
+/**
+ * Provided two composite types, determine if they "overlap". Two composite
+ * types overlap when the Sets of possible concrete types for each intersect.
+ *
+ * This is often used to determine if a fragment of a given type could possibly
+ * be visited in a context of another type.
+ */
+func doTypesOverlap(typeA Type, typeB Type) bool {
+	// So flow is aware this is constant
+	if typeA == typeB {
+		return true
+	}
+
+	possibleTypes := func(ttype Type) ([]*Object, bool) {
+		switch ttype := ttype.(type) {
+		case *Interface:
+			return ttype.GetPossibleTypes(), true
+		case *Union:
+			return ttype.GetPossibleTypes(), true
+		}
+		return nil, false
+	}
+
+	if typesA, ok := possibleTypes(typeA); ok {
+		if typesB, ok := possibleTypes(typeB); ok {
+			// If both types are abstract, then determine if there is any
+			// intersection between possible concrete types of each.
+			for _, ttypeA := range typesA {
+				for _, ttypeB := range typesB {
+					if ttypeA == ttypeB {
+						return true
+					}
+				}
+			}
+			return false
+		}
+		// Determine if the latter type is a possible concrete type of the former.
+		if typeB, ok := typeB.(*Object); ok {
+			return isPossibleType(typeA, typeB)
+		}
+		return false
+	}
+
+	if _, ok := possibleTypes(typeB); ok {
+		// Determine if the former type is a possible concrete type of the latter.
+		if typeA, ok := typeA.(*Object); ok {
+			return isPossibleType(typeB, typeA)
+		}
+	}
+
+	// Otherwise the types do not overlap.
+	return false
+}
+
+func isPossibleType(abstractType Type, possibleType *Object) bool {
+	switch abstractType := abstractType.(type) {
+	case *Interface:
+		return abstractType.IsPossibleType(possibleType)
+	case *Union:
+		return abstractType.IsPossibleType(possibleType)
+	}
+	return false
+}
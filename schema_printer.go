@@ -0,0 +1,236 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+/**
+ * Prints the types of the schema, except the introspection types and the
+ * built-in scalars, in the type definition language.
+ *
+ * Types are printed sorted by name, as well as their fields, arguments,
+ * input fields and enum values, so that the output of a schema is stable.
+ * Descriptions are printed as comments preceding the definitions they
+ * describe.
+ */
+func PrintSchema(schema Schema) string {
+	return printFilteredSchema(schema, isDefinedType)
+}
+
+// Prints the introspection types of the schema in the type definition language.
+func PrintIntrospectionSchema(schema Schema) string {
+	return printFilteredSchema(schema, isIntrospectionType)
+}
+
+func isDefinedType(typeName string) bool {
+	return !isIntrospectionType(typeName) && !isBuiltInScalar(typeName)
+}
+
+func isIntrospectionType(typeName string) bool {
+	return strings.HasPrefix(typeName, "__")
+}
+
+func isBuiltInScalar(typeName string) bool {
+	return typeName == "String" ||
+		typeName == "Boolean" ||
+		typeName == "Int" ||
+		typeName == "Float" ||
+		typeName == "ID"
+}
+
+func printFilteredSchema(schema Schema, typeFilter func(typeName string) bool) string {
+	typeMap := schema.GetTypeMap()
+	typeNames := []string{}
+	for typeName := range typeMap {
+		if typeFilter(typeName) {
+			typeNames = append(typeNames, typeName)
+		}
+	}
+	sort.Strings(typeNames)
+	types := []string{}
+	for _, typeName := range typeNames {
+		types = append(types, printType(typeMap[typeName]))
+	}
+	return strings.Join(types, "\n\n") + "\n"
+}
+
+func printType(ttype Type) string {
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		return printDescription(ttype.Description, "", true) + "scalar " + ttype.Name
+	case *Object:
+		return printObject(ttype)
+	case *Interface:
+		return printInterface(ttype)
+	case *Union:
+		return printUnion(ttype)
+	case *Enum:
+		return printEnum(ttype)
+	case *InputObject:
+		return printInputObject(ttype)
+	}
+	return ""
+}
+
+func printObject(ttype *Object) string {
+	implementedInterfaces := ""
+	if interfaces := ttype.GetInterfaces(); len(interfaces) > 0 {
+		names := []string{}
+		for _, iface := range interfaces {
+			names = append(names, iface.Name)
+		}
+		implementedInterfaces = " implements " + strings.Join(names, ", ")
+	}
+	return printDescription(ttype.Description, "", true) +
+		fmt.Sprintf("type %v%v {\n", ttype.Name, implementedInterfaces) +
+		printFields(ttype.GetFields()) + "\n" +
+		"}"
+}
+
+func printInterface(ttype *Interface) string {
+	return printDescription(ttype.Description, "", true) +
+		fmt.Sprintf("interface %v {\n", ttype.Name) +
+		printFields(ttype.GetFields()) + "\n" +
+		"}"
+}
+
+func printUnion(ttype *Union) string {
+	names := []string{}
+	for _, possibleType := range ttype.GetPossibleTypes() {
+		names = append(names, possibleType.Name)
+	}
+	return printDescription(ttype.Description, "", true) +
+		fmt.Sprintf("union %v = %v", ttype.Name, strings.Join(names, " | "))
+}
+
+func printEnum(ttype *Enum) string {
+	values := append([]*EnumValueDefinition{}, ttype.GetValues()...)
+	sort.Sort(enumValuesByName(values))
+	printed := []string{}
+	for i, value := range values {
+		printed = append(printed, printDescription(value.Description, "  ", i == 0)+
+			"  "+value.Name+printDeprecated(value.DeprecationReason))
+	}
+	return printDescription(ttype.Description, "", true) +
+		fmt.Sprintf("enum %v {\n", ttype.Name) +
+		strings.Join(printed, "\n") + "\n" +
+		"}"
+}
+
+type enumValuesByName []*EnumValueDefinition
+
+func (values enumValuesByName) Len() int           { return len(values) }
+func (values enumValuesByName) Swap(i, j int)      { values[i], values[j] = values[j], values[i] }
+func (values enumValuesByName) Less(i, j int) bool { return values[i].Name < values[j].Name }
+
+func printInputObject(ttype *InputObject) string {
+	fieldMap := ttype.GetFields()
+	fieldNames := []string{}
+	for fieldName := range fieldMap {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	printed := []string{}
+	for i, fieldName := range fieldNames {
+		field := fieldMap[fieldName]
+		printed = append(printed, printDescription(field.Description, "  ", i == 0)+
+			"  "+printInputValue(field.Name, field.Type, field.DefaultValue)+
+			printDeprecated(field.DeprecationReason))
+	}
+	return printDescription(ttype.Description, "", true) +
+		fmt.Sprintf("input %v {\n", ttype.Name) +
+		strings.Join(printed, "\n") + "\n" +
+		"}"
+}
+
+func printFields(fieldMap FieldDefinitionMap) string {
+	fieldNames := []string{}
+	for fieldName := range fieldMap {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	printed := []string{}
+	for i, fieldName := range fieldNames {
+		field := fieldMap[fieldName]
+		printed = append(printed, printDescription(field.Description, "  ", i == 0)+
+			fmt.Sprintf("  %v%v: %v", field.Name, printArgs(field.Args), field.Type)+
+			printDeprecated(field.DeprecationReason))
+	}
+	return strings.Join(printed, "\n")
+}
+
+// Arguments are printed on a single line, unless one of them has a
+// description to print.
+func printArgs(args []*Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	hasDescription := false
+	for _, arg := range args {
+		if arg.Description != "" {
+			hasDescription = true
+		}
+	}
+	printed := []string{}
+	for i, arg := range args {
+		printedArg := printInputValue(arg.Name, arg.Type, arg.DefaultValue) +
+			printDeprecated(arg.DeprecationReason)
+		if hasDescription {
+			printedArg = printDescription(arg.Description, "    ", i == 0) + "    " + printedArg
+		}
+		printed = append(printed, printedArg)
+	}
+	if !hasDescription {
+		return "(" + strings.Join(printed, ", ") + ")"
+	}
+	return "(\n" + strings.Join(printed, "\n") + "\n  )"
+}
+
+func printInputValue(name string, ttype Input, defaultValue interface{}) string {
+	printed := fmt.Sprintf("%v: %v", name, ttype)
+	if !isNullish(defaultValue) {
+		if valueAST := astFromValue(defaultValue, ttype); valueAST != nil {
+			printed += fmt.Sprintf(" = %v", printer.Print(valueAST))
+		}
+	}
+	return printed
+}
+
+// The reason of a deprecation is omitted when it is the default one.
+func printDeprecated(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	if reason == DefaultDeprecationReason {
+		return " @deprecated"
+	}
+	reasonAST := ast.NewStringValue(&ast.StringValue{
+		Value: reason,
+	})
+	return fmt.Sprintf(" @deprecated(reason: %v)", printer.Print(reasonAST))
+}
+
+// Descriptions are printed as comment lines, separated from the previous
+// definition of the block by an empty line unless they are the first one.
+func printDescription(description string, indentation string, firstInBlock bool) string {
+	if description == "" {
+		return ""
+	}
+	printed := ""
+	if !firstInBlock {
+		printed = "\n"
+	}
+	for _, line := range strings.Split(description, "\n") {
+		if line == "" {
+			printed += indentation + "#\n"
+		} else {
+			printed += indentation + "# " + line + "\n"
+		}
+	}
+	return printed
+}
@@ -0,0 +1,269 @@
+package graphql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func printForTest(t *testing.T, schema graphql.Schema, err error) string {
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return "\n" + graphql.PrintSchema(schema)
+}
+
+func printSingleFieldSchema(t *testing.T, fieldConfig *graphql.FieldConfig) string {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Root",
+			Fields: graphql.FieldConfigMap{
+				"singleField": fieldConfig,
+			},
+		}),
+	})
+	return printForTest(t, schema, err)
+}
+
+func expectPrinted(t *testing.T, expected string, printed string) {
+	if printed != expected {
+		t.Fatalf("Unexpected result, expected:\n%v\ngot:\n%v", expected, printed)
+	}
+}
+
+func TestSchemaPrinter_PrintsFieldTypes(t *testing.T) {
+	tests := []struct {
+		fieldType graphql.Output
+		printed   string
+	}{
+		{graphql.String, "String"},
+		{graphql.NewList(graphql.String), "[String]"},
+		{graphql.NewNonNull(graphql.String), "String!"},
+		{graphql.NewNonNull(graphql.NewList(graphql.String)), "[String]!"},
+		{graphql.NewList(graphql.NewNonNull(graphql.String)), "[String!]"},
+		{graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), "[String!]!"},
+	}
+	for _, test := range tests {
+		printed := printSingleFieldSchema(t, &graphql.FieldConfig{Type: test.fieldType})
+		expectPrinted(t, `
+type Root {
+  singleField: `+test.printed+`
+}
+`, printed)
+	}
+}
+
+func TestSchemaPrinter_PrintsArgumentsWithDefaultValues(t *testing.T) {
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":   &graphql.EnumValueConfig{Value: 0},
+			"GREEN": &graphql.EnumValueConfig{Value: 1},
+		},
+	})
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"color": &graphql.InputObjectFieldConfig{Type: colorType},
+			"tags":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.String)},
+		},
+	})
+	printed := printSingleFieldSchema(t, &graphql.FieldConfig{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"argOne":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 2},
+			"argTwo":   &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: `say "hi"`},
+			"argThree": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Boolean)},
+			"color":    &graphql.ArgumentConfig{Type: colorType, DefaultValue: 1},
+			"filter": &graphql.ArgumentConfig{Type: filterType, DefaultValue: map[string]interface{}{
+				"color": 0,
+				"tags":  []interface{}{"a", "b"},
+			}},
+		},
+	})
+	expectPrinted(t, `
+enum Color {
+  GREEN
+  RED
+}
+
+input Filter {
+  color: Color
+  tags: [String]
+}
+
+type Root {
+  singleField(argOne: Int = 2, argThree: Boolean!, argTwo: String = "say \"hi\"", color: Color = GREEN, filter: Filter = {color: RED, tags: ["a", "b"]}): String
+}
+`, printed)
+}
+
+func TestSchemaPrinter_PrintsInterfacesUnionsAndScalars(t *testing.T) {
+	fooType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Foo",
+		Fields: graphql.FieldConfigMap{
+			"str": &graphql.FieldConfig{Type: graphql.String},
+		},
+	})
+	baazType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Baaz",
+		Fields: graphql.FieldConfigMap{
+			"int": &graphql.FieldConfig{Type: graphql.Int},
+		},
+	})
+	barType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Bar",
+		Fields: graphql.FieldConfigMap{
+			"str": &graphql.FieldConfig{Type: graphql.String},
+			"int": &graphql.FieldConfig{Type: graphql.Int},
+		},
+		Interfaces: []*graphql.Interface{fooType, baazType},
+		IsTypeOf: func(value interface{}, info graphql.ResolveInfo) bool {
+			return true
+		},
+	})
+	bazType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Baz",
+		Fields: graphql.FieldConfigMap{
+			"odd": &graphql.FieldConfig{Type: graphql.NewScalar(graphql.ScalarConfig{
+				Name: "Odd",
+				Serialize: func(value interface{}) interface{} {
+					return value
+				},
+			})},
+		},
+		IsTypeOf: func(value interface{}, info graphql.ResolveInfo) bool {
+			return true
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Root",
+			Fields: graphql.FieldConfigMap{
+				"bar": &graphql.FieldConfig{Type: barType},
+				"union": &graphql.FieldConfig{Type: graphql.NewUnion(graphql.UnionConfig{
+					Name:  "BarOrBaz",
+					Types: []*graphql.Object{barType, bazType},
+				})},
+			},
+		}),
+	})
+	expectPrinted(t, `
+interface Baaz {
+  int: Int
+}
+
+type Bar implements Foo, Baaz {
+  int: Int
+  str: String
+}
+
+union BarOrBaz = Bar | Baz
+
+type Baz {
+  odd: Odd
+}
+
+interface Foo {
+  str: String
+}
+
+scalar Odd
+
+type Root {
+  bar: Bar
+  union: BarOrBaz
+}
+`, printForTest(t, schema, err))
+}
+
+func TestSchemaPrinter_PrintsDescriptionsAndDeprecations(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:        "Root",
+			Description: "The root type.",
+			Fields: graphql.FieldConfigMap{
+				"a": &graphql.FieldConfig{
+					Type:        graphql.String,
+					Description: "First field.",
+				},
+				"b": &graphql.FieldConfig{
+					Type:              graphql.String,
+					Description:       "Second field,\n\nover lines.",
+					DeprecationReason: `Use "a".`,
+					Args: graphql.FieldConfigArgument{
+						"x": &graphql.ArgumentConfig{Type: graphql.Int, Description: "The x."},
+						"y": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+				},
+				"c": &graphql.FieldConfig{
+					Type: graphql.NewEnum(graphql.EnumConfig{
+						Name: "Letter",
+						Values: graphql.EnumValueConfigMap{
+							"A": &graphql.EnumValueConfig{Description: "The letter A."},
+							"B": &graphql.EnumValueConfig{DeprecationReason: "Unused."},
+						},
+					}),
+				},
+			},
+		}),
+	})
+	expectPrinted(t, `
+enum Letter {
+  # The letter A.
+  A
+  B @deprecated(reason: "Unused.")
+}
+
+# The root type.
+type Root {
+  # First field.
+  a: String
+
+  # Second field,
+  #
+  # over lines.
+  b(
+    # The x.
+    x: Int
+    y: Int
+  ): String @deprecated(reason: "Use \"a\".")
+  c: Letter
+}
+`, printForTest(t, schema, err))
+}
+
+func TestSchemaPrinter_PrintsIntrospectionTypesOnlyWithPrintIntrospectionSchema(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Root",
+			Fields: graphql.FieldConfigMap{
+				"onlyField": &graphql.FieldConfig{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	printed := graphql.PrintIntrospectionSchema(schema)
+	for _, expected := range []string{
+		"type __Schema {\n",
+		"type __Type {\n",
+		"enum __TypeKind {\n",
+		"  fields(includeDeprecated: Boolean = false): [__Field!]\n",
+	} {
+		if !strings.Contains(printed, expected) {
+			t.Fatalf("Expected the introspection schema to contain %q, got:\n%v", expected, printed)
+		}
+	}
+	if strings.Contains(printed, "type Root") {
+		t.Fatalf("Expected the introspection schema not to contain the Root type, got:\n%v", printed)
+	}
+	// printing is deterministic
+	for i := 0; i < 5; i++ {
+		if again := graphql.PrintIntrospectionSchema(schema); again != printed {
+			t.Fatalf("Unexpected result, expected:\n%v\ngot:\n%v", printed, again)
+		}
+	}
+}
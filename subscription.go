@@ -0,0 +1,204 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+/**
+ * Implements the "Subscribe" algorithm described in the GraphQL
+ * specification.
+ *
+ * Subscribe parses and validates the requested subscription operation, then
+ * creates the source event stream with the Subscribe function of its root
+ * field. Each event of the stream is executed as the root value of the
+ * operation, and the result is sent on the returned channel.
+ *
+ * The channel is closed once the source stream is closed or the context of
+ * the params is done. If the subscription cannot be created, a single result
+ * holding the errors is sent before the channel is closed.
+ */
+func Subscribe(p Params) <-chan *Result {
+	requestString, persistedQueryErr := resolvePersistedQuery(p)
+	if persistedQueryErr != nil {
+		return singleResult(&Result{
+			Errors: []gqlerrors.FormattedError{*persistedQueryErr},
+		})
+	}
+	AST, errs := parseAndValidate(p, requestString)
+	if len(errs) > 0 {
+		return singleResult(&Result{
+			Errors: errs,
+		})
+	}
+
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	stream, complexity, err := createSourceEventStream(ExecuteParams{
+		Schema:        p.Schema,
+		Root:          p.RootObject,
+		AST:           AST,
+		OperationName: p.OperationName,
+		Args:          p.VariableValues,
+		Context:       ctx,
+		MaxDepth:      p.MaxDepth,
+		MaxComplexity: p.MaxComplexity,
+	})
+	if err != nil {
+		return singleResult(&Result{
+			Errors: gqlerrors.FormatErrors(err),
+		})
+	}
+
+	results := make(chan *Result)
+	go func() {
+		defer close(results)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-stream:
+				if !ok {
+					return
+				}
+				result := Execute(ExecuteParams{
+					Schema:        p.Schema,
+					Root:          event,
+					AST:           AST,
+					OperationName: p.OperationName,
+					Args:          p.VariableValues,
+					Context:       ctx,
+					Concurrency:   p.Concurrency,
+					Middlewares:   p.Middlewares,
+
+					Instrumentation: p.Instrumentation,
+					Extensions:      p.Extensions,
+
+					complexity: complexity,
+				})
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return results
+}
+
+func singleResult(result *Result) <-chan *Result {
+	results := make(chan *Result, 1)
+	results <- result
+	close(results)
+	return results
+}
+
+// Resolves the source event stream of a subscription operation, by calling
+// the Subscribe function of its single root field. The complexity of the
+// operation is returned when it was measured to check the limits.
+func createSourceEventStream(p ExecuteParams) (stream <-chan interface{}, complexity *OperationComplexity, err error) {
+	exeContext, err := buildExecutionContext(BuildExecutionCtxParams{
+		Schema:        p.Schema,
+		Root:          p.Root,
+		AST:           p.AST,
+		OperationName: p.OperationName,
+		Args:          p.Args,
+		Context:       p.Context,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	operation := exeContext.Operation
+	if operation.GetOperation() != "subscription" {
+		return nil, nil, fmt.Errorf(`Can only subscribe to subscription operations, got "%v".`, operation.GetOperation())
+	}
+	subscriptionType, err := getOperationRootType(p.Schema, operation)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the limits are checked once, when subscribing, rather than for each event
+	complexity, err = checkComplexity(exeContext, p.MaxDepth, p.MaxComplexity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := collectFields(CollectFieldsParams{
+		ExeContext:    exeContext,
+		OperationType: subscriptionType,
+		SelectionSet:  operation.GetSelectionSet(),
+	})
+	if len(fields.Names) == 0 {
+		return nil, nil, errors.New("Subscription must select a top level field.")
+	}
+	responseName := fields.Names[0]
+	fieldASTs := fields.Fields[responseName]
+	fieldAST := fieldASTs[0]
+	fieldName := ""
+	if fieldAST.Name != nil {
+		fieldName = fieldAST.Name.Value
+	}
+
+	fieldDef := getFieldDef(p.Schema, subscriptionType, fieldName)
+	if fieldDef == nil {
+		return nil, nil, NewLocatedError(
+			fmt.Sprintf(`The subscription field "%v" is not defined.`, fieldName),
+			FieldASTsToNodeASTs(fieldASTs),
+		)
+	}
+	if fieldDef.Subscribe == nil {
+		return nil, nil, NewLocatedError(
+			fmt.Sprintf(`Subscription field "%v" does not provide a "subscribe" function.`, fieldName),
+			FieldASTsToNodeASTs(fieldASTs),
+		)
+	}
+
+	args, err := getArgumentValues(fieldDef.Args, fieldAST.Arguments, exeContext.VariableValues)
+	if err != nil {
+		return nil, nil, NewLocatedError(err, FieldASTsToNodeASTs(fieldASTs))
+	}
+	info := ResolveInfo{
+		FieldName:      fieldName,
+		FieldASTs:      fieldASTs,
+		ReturnType:     fieldDef.Type,
+		ParentType:     subscriptionType,
+		Schema:         p.Schema,
+		Fragments:      exeContext.Fragments,
+		RootValue:      exeContext.Root,
+		Operation:      operation,
+		VariableValues: exeContext.VariableValues,
+		Path:           &ResponsePath{Key: responseName},
+	}
+
+	// A panicking subscribe function is reported like a returned error.
+	defer func() {
+		if r := recover(); r != nil {
+			located := NewLocatedError(r, FieldASTsToNodeASTs(fieldASTs))
+			located.Path = info.Path.AsArray()
+			stream, err = nil, located
+		}
+	}()
+	stream, err = fieldDef.Subscribe(GQLFRParams{
+		Source:  p.Root,
+		Args:    args,
+		Info:    info,
+		Context: exeContext.Context,
+	})
+	if err != nil {
+		located := NewLocatedError(err, FieldASTsToNodeASTs(fieldASTs))
+		located.Path = info.Path.AsArray()
+		return nil, nil, located
+	}
+	if stream == nil {
+		return nil, nil, NewLocatedError(
+			fmt.Sprintf(`Subscription field "%v" returned no event stream.`, fieldName),
+			FieldASTsToNodeASTs(fieldASTs),
+		)
+	}
+	return stream, complexity, nil
+}
@@ -0,0 +1,265 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type testEmail struct {
+	From    string
+	Subject string
+}
+
+var emailType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Email",
+	Fields: graphql.FieldConfigMap{
+		"from": &graphql.FieldConfig{
+			Type: graphql.String,
+			Resolve: func(p graphql.GQLFRParams) interface{} {
+				return p.Source.(testEmail).From
+			},
+		},
+		"subject": &graphql.FieldConfig{
+			Type: graphql.String,
+			Resolve: func(p graphql.GQLFRParams) interface{} {
+				return p.Source.(testEmail).Subject
+			},
+		},
+	},
+})
+
+func newSubscriptionTestSchema(t *testing.T, subscribe graphql.FieldSubscribeFn) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"inbox": &graphql.FieldConfig{
+					Type: graphql.String,
+				},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.FieldConfigMap{
+				"importantEmail": &graphql.FieldConfig{
+					Type: emailType,
+					Args: graphql.FieldConfigArgument{
+						"priority": &graphql.ArgumentConfig{
+							Type: graphql.Int,
+						},
+					},
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return p.Source
+					},
+					Subscribe: subscribe,
+				},
+				"notSubscribable": &graphql.FieldConfig{
+					Type: graphql.String,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	return schema
+}
+
+func collectResults(results <-chan *graphql.Result) []*graphql.Result {
+	collected := []*graphql.Result{}
+	for result := range results {
+		collected = append(collected, testutil.UnorderedResult(result))
+	}
+	return collected
+}
+
+func TestSubscribe_ExecutesEachEventOfTheSourceStream(t *testing.T) {
+	var receivedArgs map[string]interface{}
+	schema := newSubscriptionTestSchema(t, func(p graphql.GQLFRParams) (<-chan interface{}, error) {
+		receivedArgs = p.Args
+		events := make(chan interface{})
+		go func() {
+			defer close(events)
+			events <- testEmail{From: "yuzhi@graphql.org", Subject: "Alright"}
+			events <- testEmail{From: "hyo@graphql.org", Subject: "Tools"}
+		}()
+		return events, nil
+	})
+
+	results := graphql.Subscribe(graphql.Params{
+		Schema: schema,
+		RequestString: `subscription S($priority: Int) {
+          importantEmail(priority: $priority) { from subject }
+        }`,
+		VariableValues: map[string]interface{}{"priority": 1},
+	})
+
+	expected := []*graphql.Result{
+		&graphql.Result{
+			Data: map[string]interface{}{
+				"importantEmail": map[string]interface{}{
+					"from":    "yuzhi@graphql.org",
+					"subject": "Alright",
+				},
+			},
+		},
+		&graphql.Result{
+			Data: map[string]interface{}{
+				"importantEmail": map[string]interface{}{
+					"from":    "hyo@graphql.org",
+					"subject": "Tools",
+				},
+			},
+		},
+	}
+	if collected := collectResults(results); !reflect.DeepEqual(expected, collected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, collected))
+	}
+	expectedArgs := map[string]interface{}{"priority": 1}
+	if !reflect.DeepEqual(expectedArgs, receivedArgs) {
+		t.Fatalf("Unexpected args, Diff: %v", testutil.Diff(expectedArgs, receivedArgs))
+	}
+}
+
+func TestSubscribe_StopsWhenTheContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var subscribeCtx context.Context
+	schema := newSubscriptionTestSchema(t, func(p graphql.GQLFRParams) (<-chan interface{}, error) {
+		subscribeCtx = p.Context
+		events := make(chan interface{})
+		go func() {
+			for {
+				select {
+				case events <- testEmail{From: "yuzhi@graphql.org", Subject: "Again"}:
+				case <-p.Context.Done():
+					return
+				}
+			}
+		}()
+		return events, nil
+	})
+
+	results := graphql.Subscribe(graphql.Params{
+		Schema:        schema,
+		RequestString: `subscription S { importantEmail { subject } }`,
+		Context:       ctx,
+	})
+	for i := 0; i < 3; i++ {
+		if result := <-results; result == nil || result.HasErrors() {
+			t.Fatalf("wrong result, expected an event, got %v", result)
+		}
+	}
+	cancel()
+	// the channel is closed once cancelled, at most one pending result may
+	// still be received
+	count := 0
+	for range results {
+		count++
+	}
+	if count > 1 {
+		t.Fatalf("Unexpected results after cancellation: %v", count)
+	}
+	if subscribeCtx.Err() == nil {
+		t.Fatalf("expected the subscribe function context to be done")
+	}
+}
+
+func TestSubscribe_ReportsSubscribeErrors(t *testing.T) {
+	schema := newSubscriptionTestSchema(t, func(p graphql.GQLFRParams) (<-chan interface{}, error) {
+		return nil, errors.New("Not allowed to subscribe")
+	})
+
+	results := graphql.Subscribe(graphql.Params{
+		Schema:        schema,
+		RequestString: `subscription S { importantEmail { subject } }`,
+	})
+	expected := []*graphql.Result{
+		&graphql.Result{
+			Errors: []gqlerrors.FormattedError{
+				gqlerrors.FormattedError{
+					Message: "Not allowed to subscribe",
+					Locations: []location.SourceLocation{
+						location.SourceLocation{Line: 1, Column: 18},
+					},
+					Path: []interface{}{"importantEmail"},
+				},
+			},
+		},
+	}
+	if collected := collectResults(results); !reflect.DeepEqual(expected, collected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, collected))
+	}
+}
+
+func TestSubscribe_RequiresASubscribeFunction(t *testing.T) {
+	schema := newSubscriptionTestSchema(t, nil)
+
+	results := graphql.Subscribe(graphql.Params{
+		Schema:        schema,
+		RequestString: `subscription S { notSubscribable }`,
+	})
+	expected := []*graphql.Result{
+		&graphql.Result{
+			Errors: []gqlerrors.FormattedError{
+				gqlerrors.FormattedError{
+					Message: `Subscription field "notSubscribable" does not provide a "subscribe" function.`,
+					Locations: []location.SourceLocation{
+						location.SourceLocation{Line: 1, Column: 18},
+					},
+				},
+			},
+		},
+	}
+	if collected := collectResults(results); !reflect.DeepEqual(expected, collected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, collected))
+	}
+}
+
+func TestSubscribe_RejectsOtherOperations(t *testing.T) {
+	schema := newSubscriptionTestSchema(t, nil)
+
+	results := graphql.Subscribe(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ inbox }`,
+	})
+	expected := []*graphql.Result{
+		&graphql.Result{
+			Errors: []gqlerrors.FormattedError{
+				gqlerrors.FormattedError{
+					Message:   `Can only subscribe to subscription operations, got "query".`,
+					Locations: []location.SourceLocation{},
+				},
+			},
+		},
+	}
+	if collected := collectResults(results); !reflect.DeepEqual(expected, collected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, collected))
+	}
+}
+
+func TestSubscribe_ExecuteRequiresASubscriptionType(t *testing.T) {
+	result := testutil.UnorderedResult(graphql.Graphql(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: `subscription S { hero { name } }`,
+		// skip validation, the operation has no type to validate against
+		ValidationRules: []graphql.ValidationRule{},
+	}))
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message:   "Schema is not configured for subscriptions",
+				Locations: []location.SourceLocation{},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -14,9 +14,7 @@ var IntrospectionQuery = `
         args {
           ...InputValue
         }
-        onOperation
-        onFragment
-        onField
+        locations
       }
     }
   }
@@ -356,8 +356,19 @@ func TestParse(t *testing.T, query string) *ast.Document {
 	}
 	return astDoc
 }
+
+// TestExecute executes the params and returns the result as UnorderedResult
+// does, so that it can be compared with a result built from map literals.
 func TestExecute(t *testing.T, ep graphql.ExecuteParams) *graphql.Result {
-	return graphql.Execute(ep)
+	return UnorderedResult(graphql.Execute(ep))
+}
+
+// UnorderedResult converts the ordered maps of the result data to plain maps.
+func UnorderedResult(result *graphql.Result) *graphql.Result {
+	if data, ok := result.Data.(*graphql.OrderedMap); ok && data != nil {
+		result.Data = data.ToMap()
+	}
+	return result
 }
 
 func Diff(a, b interface{}) []string {
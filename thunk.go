@@ -0,0 +1,75 @@
+package graphql
+
+import (
+	"fmt"
+)
+
+/**
+ * Thunk is a deferred resolver result.
+ *
+ * A resolver returning a Thunk, or a list of them, defers the completion of
+ * its field: the executor first resolves every other field of the same level,
+ * then awaits the values of all the thunks returned meanwhile together, and
+ * continues the execution with their values. An error returned by Value is
+ * handled like an error returned by the resolver.
+ *
+ * Resolvers may also return a `func() interface{}` or a
+ * `func() (interface{}, error)`, which are awaited like a ThunkFn.
+ */
+type Thunk interface {
+	Value() (interface{}, error)
+}
+
+// ThunkFn is a Thunk computing its value once the executor awaits it.
+type ThunkFn func() (interface{}, error)
+
+func (fn ThunkFn) Value() (interface{}, error) {
+	return fn()
+}
+
+// Future is a Thunk computing its value in a new goroutine, as soon as it
+// is created. The computation should honor the context of the resolver.
+type Future struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func NewFuture(fn func() (interface{}, error)) *Future {
+	future := &Future{
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(future.done)
+		// a panicking computation fails the future instead of the program
+		defer func() {
+			if r := recover(); r != nil {
+				future.value, future.err = nil, fmt.Errorf("%v", r)
+			}
+		}()
+		future.value, future.err = fn()
+	}()
+	return future
+}
+
+// Value waits for the computation of the future to finish.
+func (f *Future) Value() (interface{}, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// asThunk returns the resolver result as a Thunk when it is a deferred
+// result.
+func asThunk(result interface{}) (Thunk, bool) {
+	switch result := result.(type) {
+	case Thunk:
+		return result, true
+	case func() (interface{}, error):
+		return ThunkFn(result), true
+	case func() interface{}:
+		return ThunkFn(func() (interface{}, error) {
+			return result(), nil
+		}), true
+	}
+	return nil, false
+}
@@ -0,0 +1,175 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestThunks_AwaitsTheFuturesOfALevelTogether(t *testing.T) {
+	// every future waits for all the futures of the level to be started
+	var started sync.WaitGroup
+	started.Add(3)
+	waitForLevel := func() error {
+		done := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-time.After(2 * time.Second):
+			return errors.New("futures were not started together")
+		}
+	}
+
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.FieldConfigMap{
+			"value": &graphql.FieldConfig{
+				Type: graphql.String,
+				Resolve: func(p graphql.GQLFRParams) interface{} {
+					name := p.Source.(string)
+					return graphql.NewFuture(func() (interface{}, error) {
+						started.Done()
+						if err := waitForLevel(); err != nil {
+							return nil, err
+						}
+						return name + " value", nil
+					})
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"items": &graphql.FieldConfig{
+					Type: graphql.NewList(itemType),
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return graphql.ThunkFn(func() (interface{}, error) {
+							return []string{"a", "b", "c"}, nil
+						})
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"value": "a value"},
+				map[string]interface{}{"value": "b value"},
+				map[string]interface{}{"value": "c value"},
+			},
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `{ items { value } }`),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestThunks_ReportsThunkErrorsLikeResolverErrors(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldConfigMap{
+				"thunkError": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return func() (interface{}, error) {
+							return nil, errors.New("Error getting thunkError")
+						}
+					},
+				},
+				"futurePanic": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return graphql.NewFuture(func() (interface{}, error) {
+							panic("Error getting futurePanic")
+						})
+					},
+				},
+				"unsupportedFunc": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return func() string {
+							return "unsupported"
+						}
+					},
+				},
+				"thunk": &graphql.FieldConfig{
+					Type: graphql.String,
+					Resolve: func(p graphql.GQLFRParams) interface{} {
+						return func() interface{} {
+							return "thunk value"
+						}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	query := `{
+      thunkError
+      futurePanic
+      unsupportedFunc
+      thunk
+    }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"thunkError":      nil,
+			"futurePanic":     nil,
+			"unsupportedFunc": nil,
+			"thunk":           "thunk value",
+		},
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormattedError{
+				Message:   "Error resolving func. Expected `func() interface{}` or `func() (interface{}, error)` signature",
+				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"unsupportedFunc"},
+			},
+			gqlerrors.FormattedError{
+				Message: "Error getting thunkError",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 2, Column: 7},
+				},
+				Path: []interface{}{"thunkError"},
+			},
+			gqlerrors.FormattedError{
+				Message: "Error getting futurePanic",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 3, Column: 7},
+				},
+				Path: []interface{}{"futurePanic"},
+			},
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, query),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -0,0 +1,141 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// TracingExtensionKey is the key of the tracing data in Result.Extensions.
+const TracingExtensionKey = "tracing"
+
+/**
+ * Tracing is an Instrumentation timing the parsing, validation and field
+ * resolutions of the requests, in the Apollo tracing format.
+ *
+ * The TracingData of each request is added to the extensions of its result,
+ * under TracingExtensionKey. Offsets and durations are in nanoseconds,
+ * offsets being relative to the start of the request.
+ */
+type Tracing struct {
+	NoopInstrumentation
+}
+
+type TracingData struct {
+	Version    int              `json:"version"`
+	StartTime  time.Time        `json:"startTime"`
+	EndTime    time.Time        `json:"endTime"`
+	Duration   int64            `json:"duration"`
+	Parsing    TracingPhase     `json:"parsing"`
+	Validation TracingPhase     `json:"validation"`
+	Execution  TracingExecution `json:"execution"`
+}
+
+type TracingPhase struct {
+	StartOffset int64 `json:"startOffset"`
+	Duration    int64 `json:"duration"`
+}
+
+type TracingExecution struct {
+	Resolvers []*TracingResolver `json:"resolvers"`
+}
+
+type TracingResolver struct {
+	Path        []interface{} `json:"path"`
+	ParentType  string        `json:"parentType"`
+	FieldName   string        `json:"fieldName"`
+	ReturnType  string        `json:"returnType"`
+	StartOffset int64         `json:"startOffset"`
+	Duration    int64         `json:"duration"`
+}
+
+type tracingContextKey struct{}
+
+// trace is the tracing data of a request being traced.
+type trace struct {
+	start time.Time
+	mu    sync.Mutex
+	data  *TracingData
+}
+
+func (t *trace) offset() int64 {
+	return int64(time.Since(t.start))
+}
+
+func traceOf(ctx context.Context) *trace {
+	t, _ := ctx.Value(tracingContextKey{}).(*trace)
+	return t
+}
+
+func (Tracing) InstrumentRequest(ctx context.Context, p Params) (context.Context, func(result *Result)) {
+	t := &trace{
+		start: time.Now(),
+		data: &TracingData{
+			Version: 1,
+			Execution: TracingExecution{
+				Resolvers: []*TracingResolver{},
+			},
+		},
+	}
+	return context.WithValue(ctx, tracingContextKey{}, t), func(result *Result) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.data.StartTime = t.start
+		t.data.Duration = t.offset()
+		t.data.EndTime = t.start.Add(time.Duration(t.data.Duration))
+		result.SetExtension(TracingExtensionKey, t.data)
+	}
+}
+
+func (Tracing) InstrumentParse(ctx context.Context, requestString string) func(errs []gqlerrors.FormattedError) {
+	return tracePhase(ctx, func(data *TracingData) *TracingPhase {
+		return &data.Parsing
+	})
+}
+
+func (Tracing) InstrumentValidate(ctx context.Context, document *ast.Document) func(errs []gqlerrors.FormattedError) {
+	return tracePhase(ctx, func(data *TracingData) *TracingPhase {
+		return &data.Validation
+	})
+}
+
+func tracePhase(ctx context.Context, phaseOf func(data *TracingData) *TracingPhase) func(errs []gqlerrors.FormattedError) {
+	t := traceOf(ctx)
+	if t == nil {
+		return nil
+	}
+	startOffset := t.offset()
+	return func(errs []gqlerrors.FormattedError) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		phase := phaseOf(t.data)
+		phase.StartOffset = startOffset
+		phase.Duration = t.offset() - startOffset
+	}
+}
+
+func (Tracing) InstrumentField(ctx context.Context, info ResolveInfo) func(result interface{}, err error) {
+	t := traceOf(ctx)
+	if t == nil {
+		return nil
+	}
+	resolver := &TracingResolver{
+		Path:        info.Path.AsArray(),
+		ParentType:  info.ParentType.GetName(),
+		FieldName:   info.FieldName,
+		ReturnType:  info.ReturnType.String(),
+		StartOffset: t.offset(),
+	}
+	// resolvers are listed in the order they started
+	t.mu.Lock()
+	t.data.Execution.Resolvers = append(t.data.Execution.Resolvers, resolver)
+	t.mu.Unlock()
+	return func(result interface{}, err error) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		resolver.Duration = t.offset() - resolver.StartOffset
+	}
+}
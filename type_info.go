@@ -0,0 +1,308 @@
+package graphql
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+/**
+ * TypeInfo is a utility class which, given a GraphQL schema, can keep track
+ * of the current field and type definitions at any point in a GraphQL document
+ * AST during a recursive descent by calling `Enter(node)` and `Leave(node)`.
+ *
+ * It is usually driven by the visitor through VisitWithTypeInfo:
+ *
+ *     typeInfo := NewTypeInfo(&schema)
+ *     visitor.VisitAST(astDoc, VisitWithTypeInfo(typeInfo, &visitor.VisitorOptions{
+ *       Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+ *         parentType := typeInfo.GetParentType()
+ *         ...
+ *       },
+ *     }), nil)
+ */
+type TypeInfo struct {
+	schema          *Schema
+	typeStack       []Output
+	parentTypeStack []Composite
+	inputTypeStack  []Input
+	fieldDefStack   []*FieldDefinition
+	directive       *Directive
+	argument        *Argument
+}
+
+func NewTypeInfo(schema *Schema) *TypeInfo {
+	return &TypeInfo{
+		schema: schema,
+	}
+}
+
+func (ti *TypeInfo) GetType() Output {
+	if len(ti.typeStack) > 0 {
+		return ti.typeStack[len(ti.typeStack)-1]
+	}
+	return nil
+}
+
+func (ti *TypeInfo) GetParentType() Composite {
+	if len(ti.parentTypeStack) > 0 {
+		return ti.parentTypeStack[len(ti.parentTypeStack)-1]
+	}
+	return nil
+}
+
+func (ti *TypeInfo) GetInputType() Input {
+	if len(ti.inputTypeStack) > 0 {
+		return ti.inputTypeStack[len(ti.inputTypeStack)-1]
+	}
+	return nil
+}
+
+func (ti *TypeInfo) GetFieldDef() *FieldDefinition {
+	if len(ti.fieldDefStack) > 0 {
+		return ti.fieldDefStack[len(ti.fieldDefStack)-1]
+	}
+	return nil
+}
+
+func (ti *TypeInfo) GetDirective() *Directive {
+	return ti.directive
+}
+
+func (ti *TypeInfo) GetArgument() *Argument {
+	return ti.argument
+}
+
+func (ti *TypeInfo) Enter(node ast.Node) {
+	schema := ti.schema
+	switch node := node.(type) {
+	case *ast.SelectionSet:
+		var compositeType Composite
+		switch namedType := GetNamed(ti.GetType()).(type) {
+		case *Object:
+			compositeType = namedType
+		case *Interface:
+			compositeType = namedType
+		case *Union:
+			compositeType = namedType
+		}
+		ti.parentTypeStack = append(ti.parentTypeStack, compositeType)
+	case *ast.Field:
+		var fieldDef *FieldDefinition
+		if parentType := ti.GetParentType(); parentType != nil {
+			fieldDef = typeInfoFieldDef(schema, parentType, node)
+		}
+		ti.fieldDefStack = append(ti.fieldDefStack, fieldDef)
+		if fieldDef != nil {
+			ti.typeStack = append(ti.typeStack, fieldDef.Type)
+		} else {
+			ti.typeStack = append(ti.typeStack, nil)
+		}
+	case *ast.Directive:
+		if node.Name != nil {
+			ti.directive = schema.GetDirective(node.Name.Value)
+		}
+	case *ast.OperationDefinition:
+		var ttype Output
+		switch node.Operation {
+		case "query":
+			if queryType := schema.GetQueryType(); queryType != nil {
+				ttype = queryType
+			}
+		case "mutation":
+			if mutationType := schema.GetMutationType(); mutationType != nil {
+				ttype = mutationType
+			}
+		case "subscription":
+			if subscriptionType := schema.GetSubscriptionType(); subscriptionType != nil {
+				ttype = subscriptionType
+			}
+		}
+		ti.typeStack = append(ti.typeStack, ttype)
+	case *ast.InlineFragment:
+		var outputType Output
+		if node.TypeCondition != nil {
+			outputType = outputTypeFromAST(schema, node.TypeCondition)
+		} else {
+			outputType = ti.GetType()
+		}
+		ti.typeStack = append(ti.typeStack, outputType)
+	case *ast.FragmentDefinition:
+		ti.typeStack = append(ti.typeStack, outputTypeFromAST(schema, node.TypeCondition))
+	case *ast.VariableDefinition:
+		var inputType Input
+		if ttype, _ := typeFromAST(*schema, node.Type); ttype != nil {
+			inputType = ttype
+		}
+		ti.inputTypeStack = append(ti.inputTypeStack, inputType)
+	case *ast.Argument:
+		var argDef *Argument
+		var argType Input
+		var args []*Argument
+		if directive := ti.GetDirective(); directive != nil {
+			args = directive.Args
+		} else if fieldDef := ti.GetFieldDef(); fieldDef != nil {
+			args = fieldDef.Args
+		}
+		for _, arg := range args {
+			if node.Name != nil && arg.Name == node.Name.Value {
+				argDef = arg
+				argType = arg.Type
+				break
+			}
+		}
+		ti.argument = argDef
+		ti.inputTypeStack = append(ti.inputTypeStack, argType)
+	case *ast.ListValue:
+		var itemType Input
+		inputType := ti.GetInputType()
+		if ttype, ok := inputType.(*NonNull); ok {
+			inputType = ttype.OfType
+		}
+		if ttype, ok := inputType.(*List); ok && ttype.OfType != nil {
+			itemType = ttype.OfType
+		}
+		ti.inputTypeStack = append(ti.inputTypeStack, itemType)
+	case *ast.ObjectField:
+		var fieldType Input
+		if objectType, ok := GetNamed(ti.GetInputType()).(*InputObject); ok && node.Name != nil {
+			if inputField, ok := objectType.GetFields()[node.Name.Value]; ok {
+				fieldType = inputField.Type
+			}
+		}
+		ti.inputTypeStack = append(ti.inputTypeStack, fieldType)
+	}
+}
+
+func (ti *TypeInfo) Leave(node ast.Node) {
+	switch node.GetKind() {
+	case kinds.SelectionSet:
+		if len(ti.parentTypeStack) > 0 {
+			ti.parentTypeStack = ti.parentTypeStack[:len(ti.parentTypeStack)-1]
+		}
+	case kinds.Field:
+		if len(ti.fieldDefStack) > 0 {
+			ti.fieldDefStack = ti.fieldDefStack[:len(ti.fieldDefStack)-1]
+		}
+		if len(ti.typeStack) > 0 {
+			ti.typeStack = ti.typeStack[:len(ti.typeStack)-1]
+		}
+	case kinds.Directive:
+		ti.directive = nil
+	case kinds.OperationDefinition, kinds.InlineFragment, kinds.FragmentDefinition:
+		if len(ti.typeStack) > 0 {
+			ti.typeStack = ti.typeStack[:len(ti.typeStack)-1]
+		}
+	case kinds.VariableDefinition:
+		if len(ti.inputTypeStack) > 0 {
+			ti.inputTypeStack = ti.inputTypeStack[:len(ti.inputTypeStack)-1]
+		}
+	case kinds.Argument:
+		ti.argument = nil
+		if len(ti.inputTypeStack) > 0 {
+			ti.inputTypeStack = ti.inputTypeStack[:len(ti.inputTypeStack)-1]
+		}
+	case kinds.ListValue, kinds.ObjectField:
+		if len(ti.inputTypeStack) > 0 {
+			ti.inputTypeStack = ti.inputTypeStack[:len(ti.inputTypeStack)-1]
+		}
+	}
+}
+
+/**
+ * Creates a new visitor instance which maintains a provided TypeInfo instance
+ * along with visiting visitor: TypeInfo is entered before, and left after,
+ * the visit functions of visitorOpts are called for each node.
+ *
+ * The returned visitor expects typed AST nodes, see visitor.VisitAST.
+ */
+func VisitWithTypeInfo(typeInfo *TypeInfo, visitorOpts *visitor.VisitorOptions) *visitor.VisitorOptions {
+	return &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			typeInfo.Enter(node)
+			fn := visitor.GetVisitFn(visitorOpts, false, node.GetKind())
+			if fn == nil {
+				return visitor.ActionNoChange, nil
+			}
+			action, result := fn(p)
+			switch action {
+			case visitor.ActionSkip:
+				// Leave is not called for skipped nodes.
+				typeInfo.Leave(node)
+			case visitor.ActionUpdate:
+				typeInfo.Leave(node)
+				if result, ok := result.(ast.Node); ok && !isNilNode(result) {
+					typeInfo.Enter(result)
+				}
+			}
+			return action, result
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			action, result := visitor.ActionNoChange, interface{}(nil)
+			if fn := visitor.GetVisitFn(visitorOpts, true, node.GetKind()); fn != nil {
+				action, result = fn(p)
+			}
+			typeInfo.Leave(node)
+			return action, result
+		},
+	}
+}
+
+func isNilNode(node ast.Node) bool {
+	val := reflect.ValueOf(node)
+	return val.Kind() == reflect.Ptr && val.IsNil()
+}
+
+// outputTypeFromAST resolves a type condition, returning nil instead of a
+// typed nil when the named type is unknown to the schema.
+func outputTypeFromAST(schema *Schema, typeAST ast.Type) Output {
+	if typeAST == nil {
+		return nil
+	}
+	ttype, err := typeFromAST(*schema, typeAST)
+	if err != nil || ttype == nil {
+		return nil
+	}
+	return ttype
+}
+
+/**
+ * Not exactly the same as the executor's definition of getFieldDef, in this
+ * statically evaluated environment we do not always have an Object type,
+ * and need to handle Interface and Union types.
+ */
+func typeInfoFieldDef(schema *Schema, parentType Composite, fieldAST *ast.Field) *FieldDefinition {
+	name := ""
+	if fieldAST.Name != nil {
+		name = fieldAST.Name.Value
+	}
+	if name == SchemaMetaFieldDef.Name && schema.GetQueryType() == parentType {
+		return SchemaMetaFieldDef
+	}
+	if name == TypeMetaFieldDef.Name && schema.GetQueryType() == parentType {
+		return TypeMetaFieldDef
+	}
+	if name == TypeNameMetaFieldDef.Name {
+		switch parentType.(type) {
+		case *Object, *Interface, *Union:
+			return TypeNameMetaFieldDef
+		}
+	}
+	switch parentType := parentType.(type) {
+	case *Object:
+		return parentType.GetFields()[name]
+	case *Interface:
+		return parentType.GetFields()[name]
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/visitor"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func typeName(ttype interface{}) interface{} {
+	if ttype, ok := ttype.(graphql.Type); ok && !reflect.ValueOf(ttype).IsNil() {
+		return ttype.String()
+	}
+	return nil
+}
+
+func TestTypeInfo_MaintainsTypeInfoDuringVisit(t *testing.T) {
+	visited := []interface{}{}
+	expectedVisited := []interface{}{
+		[]interface{}{"enter", "Document", nil, nil, nil, nil},
+		[]interface{}{"enter", "OperationDefinition", nil, "QueryRoot", nil, nil},
+		[]interface{}{"enter", "SelectionSet", "QueryRoot", "QueryRoot", nil, nil},
+		[]interface{}{"enter", "Field", "QueryRoot", "Human", nil, "human"},
+		[]interface{}{"enter", "Name", "QueryRoot", "Human", nil, "human"},
+		[]interface{}{"leave", "Name", "QueryRoot", "Human", nil, "human"},
+		[]interface{}{"enter", "Argument", "QueryRoot", "Human", "ID", "human"},
+		[]interface{}{"enter", "Name", "QueryRoot", "Human", "ID", "human"},
+		[]interface{}{"leave", "Name", "QueryRoot", "Human", "ID", "human"},
+		[]interface{}{"enter", "IntValue", "QueryRoot", "Human", "ID", "human"},
+		[]interface{}{"leave", "IntValue", "QueryRoot", "Human", "ID", "human"},
+		[]interface{}{"leave", "Argument", "QueryRoot", "Human", "ID", "human"},
+		[]interface{}{"enter", "SelectionSet", "Human", "Human", nil, "human"},
+		[]interface{}{"enter", "Field", "Human", "[Pet]", nil, "pets"},
+		[]interface{}{"enter", "Name", "Human", "[Pet]", nil, "pets"},
+		[]interface{}{"leave", "Name", "Human", "[Pet]", nil, "pets"},
+		[]interface{}{"enter", "SelectionSet", "Pet", "[Pet]", nil, "pets"},
+		[]interface{}{"enter", "InlineFragment", "Pet", "Dog", nil, "pets"},
+		[]interface{}{"enter", "Named", "Pet", "Dog", nil, "pets"},
+		[]interface{}{"enter", "Name", "Pet", "Dog", nil, "pets"},
+		[]interface{}{"leave", "Name", "Pet", "Dog", nil, "pets"},
+		[]interface{}{"leave", "Named", "Pet", "Dog", nil, "pets"},
+		[]interface{}{"enter", "SelectionSet", "Dog", "Dog", nil, "pets"},
+		[]interface{}{"enter", "Field", "Dog", "Boolean", nil, "doesKnowCommand"},
+		[]interface{}{"enter", "Name", "Dog", "Boolean", nil, "doesKnowCommand"},
+		[]interface{}{"leave", "Name", "Dog", "Boolean", nil, "doesKnowCommand"},
+		[]interface{}{"enter", "Argument", "Dog", "Boolean", "DogCommand", "doesKnowCommand"},
+		[]interface{}{"enter", "Name", "Dog", "Boolean", "DogCommand", "doesKnowCommand"},
+		[]interface{}{"leave", "Name", "Dog", "Boolean", "DogCommand", "doesKnowCommand"},
+		[]interface{}{"enter", "EnumValue", "Dog", "Boolean", "DogCommand", "doesKnowCommand"},
+		[]interface{}{"leave", "EnumValue", "Dog", "Boolean", "DogCommand", "doesKnowCommand"},
+		[]interface{}{"leave", "Argument", "Dog", "Boolean", "DogCommand", "doesKnowCommand"},
+		[]interface{}{"leave", "Field", "Dog", "Boolean", nil, "doesKnowCommand"},
+		[]interface{}{"leave", "SelectionSet", "Dog", "Dog", nil, "pets"},
+		[]interface{}{"leave", "InlineFragment", "Pet", "Dog", nil, "pets"},
+		[]interface{}{"leave", "SelectionSet", "Pet", "[Pet]", nil, "pets"},
+		[]interface{}{"leave", "Field", "Human", "[Pet]", nil, "pets"},
+		[]interface{}{"leave", "SelectionSet", "Human", "Human", nil, "human"},
+		[]interface{}{"leave", "Field", "QueryRoot", "Human", nil, "human"},
+		[]interface{}{"leave", "SelectionSet", "QueryRoot", "QueryRoot", nil, nil},
+		[]interface{}{"leave", "OperationDefinition", nil, "QueryRoot", nil, nil},
+		[]interface{}{"leave", "Document", nil, nil, nil, nil},
+	}
+
+	typeInfo := graphql.NewTypeInfo(testutil.DefaultRulesTestSchema)
+	record := func(action string, node ast.Node) {
+		var fieldName interface{}
+		if fieldDef := typeInfo.GetFieldDef(); fieldDef != nil {
+			fieldName = fieldDef.Name
+		}
+		visited = append(visited, []interface{}{
+			action,
+			node.GetKind(),
+			typeName(typeInfo.GetParentType()),
+			typeName(typeInfo.GetType()),
+			typeName(typeInfo.GetInputType()),
+			fieldName,
+		})
+	}
+
+	astDoc := testutil.TestParse(t, `{ human(id: 4) { pets { ... on Dog { doesKnowCommand(dogCommand: SIT) } } } }`)
+	visitor.VisitAST(astDoc, graphql.VisitWithTypeInfo(typeInfo, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			record("enter", p.Node.(ast.Node))
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+			record("leave", p.Node.(ast.Node))
+			return visitor.ActionNoChange, nil
+		},
+	}), nil)
+
+	if !reflect.DeepEqual(visited, expectedVisited) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedVisited, visited))
+	}
+}
+
+func TestTypeInfo_TracksDirectivesAndArguments(t *testing.T) {
+	visited := []interface{}{}
+	expectedVisited := []interface{}{
+		[]interface{}{"include", "if", "Boolean!"},
+		[]interface{}{nil, "atOtherHomes", "Boolean"},
+	}
+
+	typeInfo := graphql.NewTypeInfo(testutil.DefaultRulesTestSchema)
+	astDoc := testutil.TestParse(t, `{ dog @include(if: true) { isHousetrained(atOtherHomes: false) } }`)
+	visitor.VisitAST(astDoc, graphql.VisitWithTypeInfo(typeInfo, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			if _, ok := p.Node.(*ast.Argument); !ok {
+				return visitor.ActionNoChange, nil
+			}
+			var directiveName interface{}
+			if directive := typeInfo.GetDirective(); directive != nil {
+				directiveName = directive.Name
+			}
+			argument := typeInfo.GetArgument()
+			visited = append(visited, []interface{}{directiveName, argument.Name, typeName(argument.Type)})
+			return visitor.ActionNoChange, nil
+		},
+	}), nil)
+
+	if !reflect.DeepEqual(visited, expectedVisited) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedVisited, visited))
+	}
+}
+
+func TestTypeInfo_MaintainsTypeInfoDuringEdit(t *testing.T) {
+	visited := []interface{}{}
+	expectedVisited := []interface{}{
+		[]interface{}{"enter", "Field", "QueryRoot", "Human"},
+		[]interface{}{"enter", "Field", "Human", "String"},
+		[]interface{}{"leave", "Field", "Human", "String"},
+		[]interface{}{"leave", "Field", "QueryRoot", "Human"},
+	}
+
+	typeInfo := graphql.NewTypeInfo(testutil.DefaultRulesTestSchema)
+	astDoc := testutil.TestParse(t, `{ human(id: 4) { name } }`)
+	editedAST := visitor.VisitAST(astDoc, graphql.VisitWithTypeInfo(typeInfo, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(*ast.Field)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			visited = append(visited, []interface{}{
+				"enter", node.Kind, typeName(typeInfo.GetParentType()), typeName(typeInfo.GetType()),
+			})
+			// Replace "name" with an aliased "name" with a different response
+			// name, TypeInfo must stay in sync with the replacement node.
+			if node.Name.Value == "name" && node.Alias == nil {
+				return visitor.ActionUpdate, ast.NewField(&ast.Field{
+					Alias: ast.NewName(&ast.Name{Value: "fullName"}),
+					Name:  node.Name,
+				})
+			}
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+			if node, ok := p.Node.(*ast.Field); ok {
+				visited = append(visited, []interface{}{
+					"leave", node.Kind, typeName(typeInfo.GetParentType()), typeName(typeInfo.GetType()),
+				})
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}), nil)
+
+	if !reflect.DeepEqual(visited, expectedVisited) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedVisited, visited))
+	}
+	expectedQuery := `{
+  human(id: 4) {
+    fullName: name
+  }
+}
+`
+	if printed := printer.Print(editedAST.(ast.Node)); printed != expectedQuery {
+		t.Fatalf("Unexpected result, expected %v, got %v", expectedQuery, printed)
+	}
+}
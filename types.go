@@ -1,6 +1,9 @@
 package graphql
 
 import (
+	"bytes"
+	"encoding/json"
+
 	"github.com/graphql-go/graphql/gqlerrors"
 )
 
@@ -9,8 +12,103 @@ import (
 type Result struct {
 	Data   interface{}                `json:"data"`
 	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+	// Extensions holds additional entries of the response, such as the
+	// tracing data of the request.
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
 }
 
 func (r *Result) HasErrors() bool {
 	return (len(r.Errors) > 0)
 }
+
+// OrderedMap is a map of string keys to values that remembers the order in
+// which the keys were first set. The executor completes objects into
+// OrderedMaps, so the data of a Result holds its fields in the order they
+// were requested, and marshals to JSON in that order.
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{
+		keys:   []string{},
+		values: map[string]interface{}{},
+	}
+}
+
+// Set sets the value of the key, a new key is ordered after all existing ones.
+func (om *OrderedMap) Set(key string, value interface{}) {
+	if om.values == nil {
+		om.values = map[string]interface{}{}
+	}
+	if _, ok := om.values[key]; !ok {
+		om.keys = append(om.keys, key)
+	}
+	om.values[key] = value
+}
+
+func (om *OrderedMap) Get(key string) (interface{}, bool) {
+	value, ok := om.values[key]
+	return value, ok
+}
+
+// Keys returns the keys of the map in order.
+func (om *OrderedMap) Keys() []string {
+	return om.keys
+}
+
+func (om *OrderedMap) Len() int {
+	return len(om.keys)
+}
+
+// ToMap returns the content of the map as a map[string]interface{}, nested
+// OrderedMaps, including the ones in lists, are converted as well.
+func (om *OrderedMap) ToMap() map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, key := range om.keys {
+		result[key] = unorderedValue(om.values[key])
+	}
+	return result
+}
+
+func unorderedValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *OrderedMap:
+		if value == nil {
+			return nil
+		}
+		return value.ToMap()
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = unorderedValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+// MarshalJSON marshals the map to a JSON object with the keys in order.
+func (om *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range om.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		valueJSON, err := json.Marshal(om.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(valueJSON)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
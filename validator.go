@@ -3,6 +3,8 @@ package graphql
 import (
 	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
 )
 
 type ValidationResult struct {
@@ -10,7 +12,307 @@ type ValidationResult struct {
 	Errors  []gqlerrors.FormattedError
 }
 
-func ValidateDocument(schema Schema, ast *ast.Document) (vr ValidationResult) {
-	vr.IsValid = true
+/**
+ * Implements the "Validation" section of the spec.
+ *
+ * Validation runs synchronously, returning a ValidationResult with the
+ * encountered errors, or IsValid set to true if the document is valid.
+ *
+ * Each validation rule is a function which returns the visitor functions
+ * of the visitor pattern, see `SpecifiedRules` in rules.go.
+ */
+func ValidateDocument(schema Schema, astDoc *ast.Document) (vr ValidationResult) {
+	return ValidateDocumentWithRules(schema, astDoc, SpecifiedRules)
+}
+
+/**
+ * ValidateDocumentWithRules validates the document against the given rules
+ * only. Provide SpecifiedRules, possibly along with custom rules, to run the
+ * validation rules defined by the GraphQL spec.
+ */
+func ValidateDocumentWithRules(schema Schema, astDoc *ast.Document, rules []ValidationRule) (vr ValidationResult) {
+	vr.Errors = []gqlerrors.FormattedError{}
+	if astDoc == nil {
+		vr.Errors = append(vr.Errors, gqlerrors.NewFormattedError("Must provide document"))
+		return vr
+	}
+	if schema.GetQueryType() == nil {
+		vr.Errors = append(vr.Errors, gqlerrors.NewFormattedError("Must provide schema"))
+		return vr
+	}
+	vr.Errors = visitUsingRules(&schema, astDoc, rules)
+	vr.IsValid = (len(vr.Errors) == 0)
 	return vr
 }
+
+/**
+ * This uses a specialized visitor which runs multiple visitors in parallel,
+ * while maintaining the visitor skip and break API.
+ */
+func visitUsingRules(schema *Schema, astDoc *ast.Document, rules []ValidationRule) []gqlerrors.FormattedError {
+	typeInfo := NewTypeInfo(schema)
+	context := newValidationContext(schema, astDoc, typeInfo)
+
+	visitors := []*visitor.VisitorOptions{}
+	for _, rule := range rules {
+		visitors = append(visitors, rule(context))
+	}
+	// Tracks the current node being skipped by each visitor, if any.
+	skipping := make([]ast.Node, len(visitors))
+	// Tracks the visitors which have requested to stop visiting.
+	broken := make([]bool, len(visitors))
+
+	visitor.VisitAST(astDoc, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			typeInfo.Enter(node)
+			for i, v := range visitors {
+				if v == nil || broken[i] || skipping[i] != nil {
+					continue
+				}
+				fn := visitor.GetVisitFn(v, false, node.GetKind())
+				if fn == nil {
+					continue
+				}
+				action, _ := fn(p)
+				switch action {
+				case visitor.ActionSkip:
+					skipping[i] = node
+				case visitor.ActionBreak:
+					broken[i] = true
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			for i, v := range visitors {
+				if v == nil || broken[i] {
+					continue
+				}
+				if skipping[i] != nil {
+					if skipping[i] == node {
+						skipping[i] = nil
+					}
+					continue
+				}
+				fn := visitor.GetVisitFn(v, true, node.GetKind())
+				if fn == nil {
+					continue
+				}
+				action, _ := fn(p)
+				if action == visitor.ActionBreak {
+					broken[i] = true
+				}
+			}
+			typeInfo.Leave(node)
+			return visitor.ActionNoChange, nil
+		},
+	}, nil)
+
+	return context.Errors()
+}
+
+type HasSelectionSet interface {
+	GetKind() string
+	GetLoc() *ast.Location
+	GetSelectionSet() *ast.SelectionSet
+}
+
+var _ HasSelectionSet = (*ast.OperationDefinition)(nil)
+var _ HasSelectionSet = (*ast.FragmentDefinition)(nil)
+
+type VariableUsage struct {
+	Node *ast.Variable
+	Type Input
+}
+
+/**
+ * An instance of this struct is passed as the "this" context to all
+ * validators, allowing access to commonly useful contextual information
+ * from within a validation rule.
+ */
+type ValidationContext struct {
+	schema                         *Schema
+	astDoc                         *ast.Document
+	typeInfo                       *TypeInfo
+	errors                         []gqlerrors.FormattedError
+	fragments                      map[string]*ast.FragmentDefinition
+	fragmentSpreads                map[*ast.SelectionSet][]*ast.FragmentSpread
+	recursivelyReferencedFragments map[*ast.OperationDefinition][]*ast.FragmentDefinition
+	variableUsages                 map[HasSelectionSet][]*VariableUsage
+	recursiveVariableUsages        map[*ast.OperationDefinition][]*VariableUsage
+}
+
+func newValidationContext(schema *Schema, astDoc *ast.Document, typeInfo *TypeInfo) *ValidationContext {
+	return &ValidationContext{
+		schema:                         schema,
+		astDoc:                         astDoc,
+		typeInfo:                       typeInfo,
+		fragmentSpreads:                map[*ast.SelectionSet][]*ast.FragmentSpread{},
+		recursivelyReferencedFragments: map[*ast.OperationDefinition][]*ast.FragmentDefinition{},
+		variableUsages:                 map[HasSelectionSet][]*VariableUsage{},
+		recursiveVariableUsages:        map[*ast.OperationDefinition][]*VariableUsage{},
+	}
+}
+
+func (ctx *ValidationContext) ReportError(err error) {
+	ctx.errors = append(ctx.errors, gqlerrors.FormatError(err))
+}
+
+func (ctx *ValidationContext) Errors() []gqlerrors.FormattedError {
+	return ctx.errors
+}
+
+func (ctx *ValidationContext) GetSchema() *Schema {
+	return ctx.schema
+}
+
+func (ctx *ValidationContext) GetDocument() *ast.Document {
+	return ctx.astDoc
+}
+
+func (ctx *ValidationContext) GetFragment(name string) *ast.FragmentDefinition {
+	if ctx.fragments == nil {
+		fragments := map[string]*ast.FragmentDefinition{}
+		for _, statement := range ctx.astDoc.Definitions {
+			if fragment, ok := statement.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+				fragments[fragment.Name.Value] = fragment
+			}
+		}
+		ctx.fragments = fragments
+	}
+	return ctx.fragments[name]
+}
+
+func (ctx *ValidationContext) GetFragmentSpreads(node *ast.SelectionSet) []*ast.FragmentSpread {
+	if spreads, ok := ctx.fragmentSpreads[node]; ok {
+		return spreads
+	}
+	spreads := []*ast.FragmentSpread{}
+	setsToVisit := []*ast.SelectionSet{node}
+	for len(setsToVisit) > 0 {
+		set := setsToVisit[len(setsToVisit)-1]
+		setsToVisit = setsToVisit[:len(setsToVisit)-1]
+		if set == nil {
+			continue
+		}
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *ast.FragmentSpread:
+				spreads = append(spreads, selection)
+			case *ast.Field:
+				if selection.SelectionSet != nil {
+					setsToVisit = append(setsToVisit, selection.SelectionSet)
+				}
+			case *ast.InlineFragment:
+				if selection.SelectionSet != nil {
+					setsToVisit = append(setsToVisit, selection.SelectionSet)
+				}
+			}
+		}
+	}
+	ctx.fragmentSpreads[node] = spreads
+	return spreads
+}
+
+func (ctx *ValidationContext) GetRecursivelyReferencedFragments(operation *ast.OperationDefinition) []*ast.FragmentDefinition {
+	if fragments, ok := ctx.recursivelyReferencedFragments[operation]; ok {
+		return fragments
+	}
+	fragments := []*ast.FragmentDefinition{}
+	collectedNames := map[string]bool{}
+	nodesToVisit := []*ast.SelectionSet{operation.SelectionSet}
+	for len(nodesToVisit) > 0 {
+		node := nodesToVisit[len(nodesToVisit)-1]
+		nodesToVisit = nodesToVisit[:len(nodesToVisit)-1]
+		for _, spread := range ctx.GetFragmentSpreads(node) {
+			if spread.Name == nil {
+				continue
+			}
+			fragName := spread.Name.Value
+			if collectedNames[fragName] {
+				continue
+			}
+			collectedNames[fragName] = true
+			if fragment := ctx.GetFragment(fragName); fragment != nil {
+				fragments = append(fragments, fragment)
+				nodesToVisit = append(nodesToVisit, fragment.SelectionSet)
+			}
+		}
+	}
+	ctx.recursivelyReferencedFragments[operation] = fragments
+	return fragments
+}
+
+func (ctx *ValidationContext) GetVariableUsages(node HasSelectionSet) []*VariableUsage {
+	if usages, ok := ctx.variableUsages[node]; ok {
+		return usages
+	}
+	usages := []*VariableUsage{}
+	typeInfo := NewTypeInfo(ctx.schema)
+	visitor.VisitAST(node, VisitWithTypeInfo(typeInfo, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.VariableDefinition: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.Variable: visitor.NamedVisitFuncs{
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Variable); ok {
+						usages = append(usages, &VariableUsage{
+							Node: node,
+							Type: typeInfo.GetInputType(),
+						})
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}), nil)
+	ctx.variableUsages[node] = usages
+	return usages
+}
+
+func (ctx *ValidationContext) GetRecursiveVariableUsages(operation *ast.OperationDefinition) []*VariableUsage {
+	if usages, ok := ctx.recursiveVariableUsages[operation]; ok {
+		return usages
+	}
+	usages := ctx.GetVariableUsages(operation)
+	for _, fragment := range ctx.GetRecursivelyReferencedFragments(operation) {
+		usages = append(usages, ctx.GetVariableUsages(fragment)...)
+	}
+	ctx.recursiveVariableUsages[operation] = usages
+	return usages
+}
+
+func (ctx *ValidationContext) GetType() Output {
+	return ctx.typeInfo.GetType()
+}
+
+func (ctx *ValidationContext) GetParentType() Composite {
+	return ctx.typeInfo.GetParentType()
+}
+
+func (ctx *ValidationContext) GetInputType() Input {
+	return ctx.typeInfo.GetInputType()
+}
+
+func (ctx *ValidationContext) GetFieldDef() *FieldDefinition {
+	return ctx.typeInfo.GetFieldDef()
+}
+
+func (ctx *ValidationContext) GetDirective() *Directive {
+	return ctx.typeInfo.GetDirective()
+}
+
+func (ctx *ValidationContext) GetArgument() *Argument {
+	return ctx.typeInfo.GetArgument()
+}
@@ -272,6 +272,85 @@ func isValidInputValue(value interface{}, ttype Input) bool {
 	return false
 }
 
+/**
+ * Utility for validators which determines if a value literal AST is valid given
+ * an input type.
+ *
+ * Note that this only validates literal values, variables are assumed to
+ * provide values of the correct type.
+ */
+func isValidLiteralValue(ttype Input, valueAST ast.Value) bool {
+	// A value must be provided if the type is non-null.
+	if ttype, ok := ttype.(*NonNull); ok {
+		if valueAST == nil {
+			return false
+		}
+		return isValidLiteralValue(ttype.OfType, valueAST)
+	}
+
+	if valueAST == nil {
+		return true
+	}
+
+	// This function only tests literals, and assumes variables will provide
+	// values of the correct type.
+	if valueAST.GetKind() == kinds.Variable {
+		return true
+	}
+
+	switch ttype := ttype.(type) {
+	case *List:
+		// Lists accept a non-list value as a list of one.
+		itemType := ttype.OfType
+		if valueAST, ok := valueAST.(*ast.ListValue); ok {
+			for _, itemAST := range valueAST.Values {
+				if !isValidLiteralValue(itemType, itemAST) {
+					return false
+				}
+			}
+			return true
+		}
+		return isValidLiteralValue(itemType, valueAST)
+
+	case *InputObject:
+		// Input objects check each defined field and look for undefined fields.
+		valueAST, ok := valueAST.(*ast.ObjectValue)
+		if !ok {
+			return false
+		}
+		fields := ttype.GetFields()
+
+		// Ensure every provided field is defined.
+		fieldASTMap := map[string]*ast.ObjectField{}
+		for _, fieldAST := range valueAST.Fields {
+			if fieldAST.Name == nil {
+				continue
+			}
+			if _, ok := fields[fieldAST.Name.Value]; !ok {
+				return false
+			}
+			fieldASTMap[fieldAST.Name.Value] = fieldAST
+		}
+		// Ensure every defined field is valid.
+		for fieldName, field := range fields {
+			var fieldValueAST ast.Value
+			if fieldAST, ok := fieldASTMap[fieldName]; ok {
+				fieldValueAST = fieldAST.Value
+			}
+			if !isValidLiteralValue(field.Type, fieldValueAST) {
+				return false
+			}
+		}
+		return true
+
+	case *Scalar:
+		return ttype.ParseLiteral(valueAST) != nil
+	case *Enum:
+		return ttype.ParseLiteral(valueAST) != nil
+	}
+	return false
+}
+
 // Returns true if a value is null, undefined, or NaN.
 func isNullish(value interface{}) bool {
 	if value, ok := value.(string); ok {